@@ -0,0 +1,169 @@
+package tester
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/pkg/errors"
+)
+
+// MaxBlobsPerTx is the highest number of blobs a transaction can carry, as many as a block can hold.
+const MaxBlobsPerTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+
+// NewBlobTx returns a CreateTx sending EIP-4844 transactions that carry blobs of
+// random data, from the sender to itself.
+//
+// The fee caps are taken from opts, set by the gas pricer of the generator, and
+// the blob fee cap from pricer. The missing ones are fetched from backend for
+// every transaction, as bind.TransactOpts does.
+//
+// Parameters:
+// - backend: the backend used to fill in the missing transaction fields.
+// - blobs: the number of blobs of every transaction, from 1 to MaxBlobsPerTx.
+// - blobFeeCap: the blob gas fee cap, nil for twice the blob base fee of the latest block.
+// - pricer: the gas pricer of the generator, nil when there is none.
+//
+// Returns:
+// - CreateTx: the blob transaction builder.
+// - error: An error if the number of blobs or the blob fee cap is invalid.
+func NewBlobTx(backend bind.ContractBackend, blobs int, blobFeeCap *big.Int, pricer *GasPricer) (CreateTx, error) {
+	if blobs < 1 || blobs > MaxBlobsPerTx {
+		return nil, errors.Errorf("invalid number of blobs: %d, must be between 1 and %d", blobs, MaxBlobsPerTx)
+	}
+	if blobFeeCap != nil && blobFeeCap.Sign() < 0 {
+		return nil, errors.Errorf("invalid blob fee cap: %s, must not be negative", blobFeeCap)
+	}
+
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		ctx := opts.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		tipCap, feeCap, blobCap := opts.GasTipCap, opts.GasFeeCap, blobFeeCap
+		if blobCap == nil {
+			blobCap = pricer.BlobFeeCap()
+		}
+		if tipCap == nil || feeCap == nil || blobCap == nil {
+			head, err := backend.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to fetch the latest header")
+			}
+			if head.BaseFee == nil || head.ExcessBlobGas == nil {
+				return nil, errors.New("the chain does not accept blob transactions, cancun is not active")
+			}
+			if tipCap == nil {
+				if tipCap, err = backend.SuggestGasTipCap(ctx); err != nil {
+					return nil, errors.Wrap(err, "failed to suggest the gas tip cap")
+				}
+			}
+			if feeCap == nil {
+				feeCap = new(big.Int).Add(tipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+			}
+			if blobCap == nil {
+				blobCap = new(big.Int).Mul(eip4844.CalcBlobFee(*head.ExcessBlobGas), big.NewInt(2))
+			}
+		}
+
+		var (
+			nonce uint64
+			err   error
+		)
+		if opts.Nonce != nil {
+			nonce = opts.Nonce.Uint64()
+		} else if nonce, err = backend.PendingNonceAt(ctx, opts.From); err != nil {
+			return nil, errors.Wrap(err, "failed to fetch the pending nonce")
+		}
+		gas := opts.GasLimit
+		if gas == 0 {
+			gas = params.TxGas
+		}
+		value := opts.Value
+		if value == nil {
+			value = new(big.Int)
+		}
+
+		blobTx := &types.BlobTx{
+			Nonce: nonce,
+			Gas:   gas,
+			To:    opts.From,
+		}
+		for _, field := range []struct {
+			name string
+			dst  **uint256.Int
+			v    *big.Int
+		}{
+			{"gas tip cap", &blobTx.GasTipCap, tipCap},
+			{"gas fee cap", &blobTx.GasFeeCap, feeCap},
+			{"value", &blobTx.Value, value},
+			{"blob fee cap", &blobTx.BlobFeeCap, blobCap},
+		} {
+			if *field.dst, err = toUint256(field.name, field.v); err != nil {
+				return nil, err
+			}
+		}
+
+		sidecar, err := randomSidecar(blobs)
+		if err != nil {
+			return nil, err
+		}
+		blobTx.BlobHashes, blobTx.Sidecar = sidecar.BlobHashes(), sidecar
+		tx, err := opts.Signer(opts.From, types.NewTx(blobTx))
+		if err != nil {
+			return nil, err
+		}
+		if opts.NoSend {
+			return tx, nil
+		}
+		return tx, backend.SendTransaction(ctx, tx)
+	}, nil
+}
+
+// toUint256 converts a fee or a value of a blob transaction, which can neither
+// be negative nor take more than 256 bits.
+func toUint256(name string, v *big.Int) (*uint256.Int, error) {
+	if v.Sign() < 0 {
+		return nil, errors.Errorf("invalid %s: %s, must not be negative", name, v)
+	}
+	u, overflow := uint256.FromBig(v)
+	if overflow {
+		return nil, errors.Errorf("invalid %s: %s, must fit in 256 bits", name, v)
+	}
+	return u, nil
+}
+
+// randomSidecar returns blobs of random data with their KZG commitments and proofs.
+func randomSidecar(blobs int) (*types.BlobTxSidecar, error) {
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       make([]kzg4844.Blob, blobs),
+		Commitments: make([]kzg4844.Commitment, blobs),
+		Proofs:      make([]kzg4844.Proof, blobs),
+	}
+	for i := range sidecar.Blobs {
+		if _, err := rand.Read(sidecar.Blobs[i][:]); err != nil {
+			return nil, err
+		}
+		// keep every 32-byte field element below the BLS12-381 modulus
+		for j := 0; j < len(sidecar.Blobs[i]); j += 32 {
+			sidecar.Blobs[i][j] = 0
+		}
+
+		commitment, err := kzg4844.BlobToCommitment(sidecar.Blobs[i])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to commit to the blob")
+		}
+		proof, err := kzg4844.ComputeBlobProof(sidecar.Blobs[i], commitment)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to prove the blob")
+		}
+		sidecar.Commitments[i], sidecar.Proofs[i] = commitment, proof
+	}
+	return sidecar, nil
+}
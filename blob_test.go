@@ -0,0 +1,100 @@
+package tester
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobTxResign(t *testing.T) {
+	sidecar, err := randomSidecar(2)
+	require.NoError(t, err)
+	for i := range sidecar.Blobs {
+		require.NoError(t, kzg4844.VerifyBlobProof(sidecar.Blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]))
+	}
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(1337)
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.BlobTx{
+		ChainID:    uint256.MustFromBig(chainID),
+		Nonce:      1,
+		GasTipCap:  uint256.NewInt(10),
+		GasFeeCap:  uint256.NewInt(100),
+		Gas:        21000,
+		To:         crypto.PubkeyToAddress(key.PublicKey),
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.NewInt(50),
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	require.NoError(t, err)
+
+	resigned, err := signTx(tx, key, func(f *txFields) {
+		f.nonce = 2
+		f.blobFeeCap = bumpFee(f.blobFeeCap, blobPriceBump)
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), resigned.Type())
+	require.Equal(t, uint64(2), resigned.Nonce())
+	require.Equal(t, int64(100), resigned.BlobGasFeeCap().Int64())
+	require.Equal(t, tx.BlobHashes(), resigned.BlobHashes())
+	require.Equal(t, sidecar, resigned.BlobTxSidecar())
+
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), resigned)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), from)
+}
+
+func TestNewBlobTx(t *testing.T) {
+	_, err := NewBlobTx(nil, 0, nil, nil)
+	require.Error(t, err)
+	_, err = NewBlobTx(nil, MaxBlobsPerTx+1, nil, nil)
+	require.Error(t, err)
+	_, err = NewBlobTx(nil, 1, big.NewInt(-1), nil)
+	require.Error(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	newOpts := func() *bind.TransactOpts {
+		opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+		require.NoError(t, err)
+		opts.NoSend = true
+		opts.Nonce = big.NewInt(4)
+		opts.GasTipCap, opts.GasFeeCap = big.NewInt(10), big.NewInt(100)
+		return opts
+	}
+
+	// every fee is given, the builder does not need the backend
+	createTx, err := NewBlobTx(nil, 2, big.NewInt(50), nil)
+	require.NoError(t, err)
+	tx, err := createTx(newOpts())
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type())
+	require.Equal(t, uint64(4), tx.Nonce())
+	require.Equal(t, int64(50), tx.BlobGasFeeCap().Int64())
+	require.Len(t, tx.BlobHashes(), 2)
+
+	// the blob fee cap follows the blob base fee of the pricer
+	createTx, err = NewBlobTx(nil, 1, nil, &GasPricer{blobBaseFee: big.NewInt(3)})
+	require.NoError(t, err)
+	tx, err = createTx(newOpts())
+	require.NoError(t, err)
+	require.Equal(t, int64(6), tx.BlobGasFeeCap().Int64())
+
+	// a negative fee is an error, not a panic
+	opts := newOpts()
+	opts.GasTipCap = big.NewInt(-1)
+	_, err = createTx(opts)
+	require.Error(t, err)
+	opts = newOpts()
+	opts.GasFeeCap = new(big.Int).Lsh(big.NewInt(1), 256)
+	_, err = createTx(opts)
+	require.Error(t, err)
+}
@@ -0,0 +1,232 @@
+package tester
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/olekukonko/tablewriter"
+)
+
+type blockRecord struct {
+	Number     uint64 `csv:"number"`
+	Timestamp  uint64 `csv:"timestamp"`
+	BlockTime  uint64 `csv:"block_time"`
+	TxCount    int    `csv:"tx_count"`
+	OurTxCount int    `csv:"our_tx_count"`
+	GasUsed    uint64 `csv:"gas_used"`
+	GasLimit   uint64 `csv:"gas_limit"`
+}
+
+// BlockWatcher follows the new blocks of the chain while the Transactor runs,
+// and measures what the chain actually processed.
+//
+// All methods are safe to call on a nil *BlockWatcher, which disables them.
+type BlockWatcher struct {
+	eth *ethclient.Client
+
+	mu         sync.Mutex
+	hashes     map[common.Hash]struct{}
+	blocks     []*blockRecord
+	parentTime uint64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewBlockWatcher creates a new BlockWatcher.
+//
+// eth: an instance of ethclient.Client used for fetching the blocks.
+// Returns a pointer to the newly created BlockWatcher instance.
+func NewBlockWatcher(eth *ethclient.Client) *BlockWatcher {
+	return &BlockWatcher{
+		eth:    eth,
+		hashes: make(map[common.Hash]struct{}),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Track marks a transaction hash as sent by the tester.
+//
+// It is called before the transaction is sent, a transaction included in a
+// block processed before the send returns is counted as well.
+func (w *BlockWatcher) Track(hash common.Hash) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.hashes[hash] = struct{}{}
+	w.mu.Unlock()
+}
+
+// Start follows the blocks produced after the current head until Stop is called.
+func (w *BlockWatcher) Start() {
+	if w == nil {
+		return
+	}
+	defer close(w.done)
+
+	ctx := context.Background()
+	head, err := w.eth.HeaderByNumber(ctx, nil)
+	if err != nil {
+		slog.Error("failed to fetch the latest block", "err", err)
+		return
+	}
+	w.parentTime = head.Time
+	followBlocks(ctx, w.eth, head.Number.Uint64(), w.stop, w.addBlock)
+}
+
+// Stop processes the blocks produced so far and stops following new ones.
+func (w *BlockWatcher) Stop() {
+	if w == nil {
+		return
+	}
+	close(w.stop)
+	<-w.done
+}
+
+func (w *BlockWatcher) addBlock(block *types.Block) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	rd := &blockRecord{
+		Number:    block.NumberU64(),
+		Timestamp: block.Time(),
+		TxCount:   len(block.Transactions()),
+		GasUsed:   block.GasUsed(),
+		GasLimit:  block.GasLimit(),
+	}
+	if n := len(w.blocks); n > 0 {
+		rd.BlockTime = rd.Timestamp - w.blocks[n-1].Timestamp
+	} else {
+		rd.BlockTime = rd.Timestamp - w.parentTime
+	}
+	for _, tx := range block.Transactions() {
+		if _, ok := w.hashes[tx.Hash()]; ok {
+			rd.OurTxCount++
+		}
+	}
+	w.blocks = append(w.blocks, rd)
+	slog.Info("new block", "number", rd.Number, "txs", rd.TxCount, "ours", rd.OurTxCount, "gasUsed", rd.GasUsed)
+}
+
+// printResult prints the on-chain throughput of the blocks produced during the run.
+func (w *BlockWatcher) printResult() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.blocks) == 0 {
+		return
+	}
+	if err := SaveToCSV("./blocks.csv", w.blocks); err != nil {
+		slog.Error("failed to save blocks", "err", err)
+	}
+
+	var (
+		txs, ours         int
+		gasUsed, gasLimit uint64
+	)
+	for _, rd := range w.blocks {
+		txs += rd.TxCount
+		ours += rd.OurTxCount
+		gasUsed += rd.GasUsed
+		gasLimit += rd.GasLimit
+	}
+	seconds := float64(w.blocks[len(w.blocks)-1].Timestamp - w.parentTime)
+	perSecond := func(v float64) string {
+		if seconds == 0 {
+			return "-"
+		}
+		return strconv.FormatFloat(v/seconds, 'f', 2, 64)
+	}
+
+	fmt.Println("Output on-chain statistics:")
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Blocks", "FirstBlock", "LastBlock", "Txs", "OurTxs", "TotalTime", "AvgBlockTime", "TPS", "OurTPS", "Gas/s", "GasUtilization"})
+	table.SetAutoFormatHeaders(false)
+	table.Append([]string{
+		strconv.Itoa(len(w.blocks)),
+		strconv.FormatUint(w.blocks[0].Number, 10),
+		strconv.FormatUint(w.blocks[len(w.blocks)-1].Number, 10),
+		strconv.Itoa(txs),
+		strconv.Itoa(ours),
+		(time.Duration(seconds) * time.Second).String(),
+		(time.Duration(seconds / float64(len(w.blocks)) * float64(time.Second))).String(),
+		perSecond(float64(txs)),
+		perSecond(float64(ours)),
+		perSecond(float64(gasUsed)),
+		strconv.FormatFloat(float64(gasUsed)/float64(gasLimit)*100, 'f', 2, 64) + "%",
+	})
+	table.Render()
+}
+
+// followBlocks calls fn with every block after from, in order, until stop is
+// closed. The blocks produced before stop was closed are still processed.
+//
+// It subscribes to the new heads when the endpoint supports it, and falls
+// back to polling the latest block number otherwise.
+func followBlocks(ctx context.Context, eth *ethclient.Client, from uint64, stop <-chan struct{}, fn func(*types.Block)) {
+	const pollInterval = 500 * time.Millisecond
+
+	var (
+		heads    = make(chan *types.Header, 64)
+		subErr   <-chan error
+		interval = pollInterval
+	)
+	sub, err := eth.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		slog.Info("new heads subscription is not available, polling blocks", "err", err)
+	} else {
+		defer sub.Unsubscribe()
+		subErr = sub.Err()
+		// the subscription drives the loop, polling is just a safety net
+		interval = 5 * time.Second
+	}
+
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	next := from + 1
+	for {
+		stopped := false
+		select {
+		case <-heads:
+		case err := <-subErr:
+			slog.Error("new heads subscription dropped, polling blocks", "err", err)
+			subErr = nil
+			tick.Reset(pollInterval)
+		case <-tick.C:
+		case <-stop:
+			stopped = true
+		}
+
+		head, err := eth.BlockNumber(ctx)
+		if err != nil {
+			slog.Error("failed to fetch the latest block number", "err", err)
+		}
+		for ; err == nil && next <= head; next++ {
+			block, err := eth.BlockByNumber(ctx, new(big.Int).SetUint64(next))
+			if err != nil {
+				slog.Error("failed to fetch block", "number", next, "err", err)
+				break
+			}
+			fn(block)
+		}
+		if stopped {
+			return
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+
+	tester "github.com/dreamer-zq/evm-tester"
+)
+
+var (
+	flagRecipients = "to"
+	flagAmount     = "amount"
+	flagTreasury   = "treasury"
+)
+
+// NewAccountsCmd creates a new instance of the cobra.Command for the "accounts" command.
+//
+// Returns a pointer to the cobra.Command.
+func NewAccountsCmd() *cobra.Command {
+	accountsCmd := &cobra.Command{
+		Use:   "accounts",
+		Short: "Manage the test accounts",
+	}
+
+	accountsCmd.AddCommand(FundCmd())
+	accountsCmd.AddCommand(SweepCmd())
+
+	accountsCmd.PersistentFlags().String(flagURL, "", "turbo endpoint url")
+	accountsCmd.PersistentFlags().Int64(flagChainID, 0, "turbo chain-id")
+	accountsCmd.MarkPersistentFlagRequired(flagURL)
+	accountsCmd.MarkPersistentFlagRequired(flagChainID)
+	return accountsCmd
+}
+
+// FundCmd returns a cobra command distributing native tokens from a faucet account.
+//
+// The transfers are sent with the transactor, their receipts are verified and
+// the final balances of the funded accounts are printed.
+func FundCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fund",
+		Short: "Send native tokens from a faucet account to the test accounts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := loadClientFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			faucet, err := loadPrivateKey(cmd)
+			if err != nil {
+				return err
+			}
+			if faucet == nil {
+				return errors.Errorf("`--%s` or `--%s` of the faucet account is required", flagPrivateKey, flagPrivateKeystore)
+			}
+
+			recipients, err := loadRecipients(cmd)
+			if err != nil {
+				return err
+			}
+			if len(recipients) == 0 {
+				return errors.Errorf("no account to fund, use `--%s`, `--%s`, `--%s` or `--%s`", flagRecipients, flagSenderKeys, flagKeystore, flagMnemonic)
+			}
+
+			amountStr, err := cmd.Flags().GetString(flagAmount)
+			if err != nil {
+				return err
+			}
+			amount, ok := new(big.Int).SetString(amountStr, 10)
+			if !ok || amount.Sign() <= 0 {
+				return errors.Errorf("invalid amount: %s", amountStr)
+			}
+
+			senders, err := tester.NewSenderPool(context.Background(), conf.client, []*ecdsa.PrivateKey{faucet})
+			if err != nil {
+				return err
+			}
+
+			generator, err := getTransferGenerator(cmd, conf, senders, tester.ToRecipients(recipients, amount))
+			if err != nil {
+				return err
+			}
+
+			transactor, err := getAccountsTransactor(cmd, conf, generator)
+			if err != nil {
+				return err
+			}
+			transactor.Run()
+			return printBalances(conf.client, recipients)
+		},
+	}
+	addAccountsTxFlags(cmd)
+	cmd.Flags().StringSlice(flagRecipients, []string{}, "addresses of the accounts to fund")
+	cmd.Flags().String(flagAmount, "", "amount of native tokens sent to every account, in wei")
+	cmd.Flags().String(flagSenderKeys, "", "file of the private keys of the accounts to fund, one per line")
+	addKeystoreFlags(cmd)
+	addPrivateKeystoreFlag(cmd, "faucet")
+	addMnemonicFlags(cmd)
+	cmd.MarkFlagRequired(flagAmount)
+	return cmd
+}
+
+// SweepCmd returns a cobra command sending the leftover native tokens of the test accounts back to a treasury.
+//
+// Every account sends its balance minus the worst-case fee of the transfer, the
+// receipts are verified and the final balances are printed.
+func SweepCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Send the leftover native tokens of the test accounts back to a treasury",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := loadClientFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			treasuryStr, err := cmd.Flags().GetString(flagTreasury)
+			if err != nil {
+				return err
+			}
+			if !common.IsHexAddress(treasuryStr) {
+				return errors.Errorf("invalid treasury address: %s", treasuryStr)
+			}
+			treasury := common.HexToAddress(treasuryStr)
+
+			keys, err := loadSenderKeys(cmd)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				return errors.Errorf("no account to sweep, use `--%s`, `--%s` or `--%s`", flagSenderKeys, flagKeystore, flagMnemonic)
+			}
+
+			gasLimit, err := cmd.Flags().GetUint64(flagGasLimit)
+			if err != nil {
+				return err
+			}
+			if gasLimit == 0 {
+				gasLimit = params.TxGas
+			}
+
+			gasFeeCap, gasTipCap, err := loadSweepFees(cmd, conf.client)
+			if err != nil {
+				return err
+			}
+			fee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasFeeCap)
+
+			var (
+				sweepKeys []*ecdsa.PrivateKey
+				accounts  []common.Address
+				values    = make(map[common.Address]*big.Int)
+			)
+			for _, key := range keys {
+				addr := crypto.PubkeyToAddress(key.PublicKey)
+				if _, ok := values[addr]; ok || addr == treasury {
+					continue
+				}
+				balance, err := conf.client.BalanceAt(context.Background(), addr, nil)
+				if err != nil {
+					return errors.Wrapf(err, "failed to fetch the balance of %s", addr)
+				}
+				accounts = append(accounts, addr)
+				if balance.Cmp(fee) <= 0 {
+					slog.Info("skip account, balance does not cover the fee", "account", addr, "balance", balance, "fee", fee)
+					continue
+				}
+				values[addr] = balance.Sub(balance, fee)
+				sweepKeys = append(sweepKeys, key)
+			}
+			if len(sweepKeys) == 0 {
+				fmt.Println("No account holds more than the fee of a transfer")
+				return printBalances(conf.client, append(accounts, treasury))
+			}
+
+			senders, err := tester.NewSenderPool(context.Background(), conf.client, sweepKeys)
+			if err != nil {
+				return err
+			}
+
+			generator, err := getTransferGenerator(cmd, conf, senders, tester.FromSenders(treasury, values),
+				tester.SetGasLimit(gasLimit),
+				tester.SetGasFeeCap(gasFeeCap),
+				tester.SetGasTipCap(gasTipCap),
+			)
+			if err != nil {
+				return err
+			}
+
+			transactor, err := getAccountsTransactor(cmd, conf, generator)
+			if err != nil {
+				return err
+			}
+			transactor.Run()
+			return printBalances(conf.client, append(accounts, treasury))
+		},
+	}
+	addAccountsTxFlags(cmd)
+	cmd.Flags().String(flagTreasury, "", "address receiving the leftover native tokens")
+	cmd.Flags().String(flagSenderKeys, "", "file of the private keys of the accounts to sweep, one per line")
+	addKeystoreFlags(cmd)
+	addMnemonicFlags(cmd)
+	cmd.MarkFlagRequired(flagTreasury)
+	return cmd
+}
+
+// loadSweepFees returns the fee caps of the sweep transactions.
+//
+// The fee left in every account must be known before signing, so the caps fall
+// back to the suggested tip and twice the current base fee instead of the oracle.
+func loadSweepFees(cmd *cobra.Command, client *ethclient.Client) (*big.Int, *big.Int, error) {
+	gasFeeCap, err := cmd.Flags().GetInt64(flagGasFeeCap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gasTipCap, err := cmd.Flags().GetInt64(flagGasTipCap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tipCap := big.NewInt(gasTipCap)
+	if gasTipCap == 0 {
+		if tipCap, err = client.SuggestGasTipCap(context.Background()); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to suggest the gas tip cap")
+		}
+	}
+	if gasFeeCap > 0 {
+		return big.NewInt(gasFeeCap), tipCap, nil
+	}
+
+	head, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to fetch the latest header")
+	}
+	feeCap := new(big.Int).Set(tipCap)
+	if head.BaseFee != nil {
+		feeCap.Add(feeCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	}
+	return feeCap, tipCap, nil
+}
+
+func addAccountsTxFlags(cmd *cobra.Command) {
+	addTxFlags(cmd)
+	cmd.Flags().Uint64(flagBatchSize, 100, "number of transactions per batch")
+	cmd.Flags().Int(flagUserNum, 0, "maximum number of concurrent users")
+	cmd.Flags().String(flagSendMode, "parallel", "transaction sending mode, `oneByOne`,`parallel` ,`segment` or `batch`")
+	cmd.Flags().String(flagVerifyMode, "receipt", "verification mode, `receipt` polls every transaction receipt, `block` follows the new blocks (subscribes on websocket endpoints)")
+}
+
+// loadPrivateKey loads the key of `--private-key`, or the key of the keystore
+// file of `--private-keystore`.
+//
+// It returns no key when neither is configured.
+func loadPrivateKey(cmd *cobra.Command) (*ecdsa.PrivateKey, error) {
+	privKeyStr, err := cmd.Flags().GetString(flagPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if privKeyStr != "" {
+		return crypto.HexToECDSA(strings.TrimPrefix(privKeyStr, "0x"))
+	}
+
+	path, err := cmd.Flags().GetString(flagPrivateKeystore)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil
+	}
+	keys, err := decryptKeystore(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != 1 {
+		return nil, errors.Errorf("`--%s` requires a keystore of one account, %s has %d", flagPrivateKeystore, path, len(keys))
+	}
+	return keys[0], nil
+}
+
+func loadRecipients(cmd *cobra.Command) ([]common.Address, error) {
+	addrs, err := cmd.Flags().GetStringSlice(flagRecipients)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []common.Address
+	for _, addr := range addrs {
+		if !common.IsHexAddress(addr) {
+			return nil, errors.Errorf("invalid address: %s", addr)
+		}
+		recipients = append(recipients, common.HexToAddress(addr))
+	}
+
+	keys, err := loadSenderKeys(cmd)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		recipients = append(recipients, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return recipients, nil
+}
+
+func getTransferGenerator(cmd *cobra.Command, conf *GlobalConfig, senders *tester.SenderPool, next tester.Recipient, opts ...tester.Option) (*tester.TxGenerator, error) {
+	return getAccountsGenerator(cmd, conf, senders, tester.NewTransferTx(conf.client, next), opts...)
+}
+
+// getAccountsGenerator returns the generator of the accounts commands, sending
+// the transactions of createTx from senders.
+func getAccountsGenerator(cmd *cobra.Command, conf *GlobalConfig, senders *tester.SenderPool, createTx tester.CreateTx, opts ...tester.Option) (*tester.TxGenerator, error) {
+	batchSize, err := cmd.Flags().GetUint64(flagBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	gasLimit, err := cmd.Flags().GetUint64(flagGasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	gasFeeCap, err := cmd.Flags().GetInt64(flagGasFeeCap)
+	if err != nil {
+		return nil, err
+	}
+
+	gasTipCap, err := cmd.Flags().GetInt64(flagGasTipCap)
+	if err != nil {
+		return nil, err
+	}
+
+	txType, accessLists, err := loadTxTypeFlags(cmd, conf.client)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]tester.Option{
+		tester.SetBatchSize(batchSize),
+		tester.SetGasLimit(gasLimit),
+		tester.SetGasFeeCap(feeFlag(gasFeeCap)),
+		tester.SetGasTipCap(feeFlag(gasTipCap)),
+		tester.SetTxType(txType, accessLists, conf.client),
+		tester.SetSenderPool(senders),
+	}, opts...)
+
+	return tester.NewTxGenerator(
+		conf.chainID,
+		createTx,
+		tester.NewPool(1, "accounts"),
+		opts...,
+	), nil
+}
+
+func getAccountsTransactor(cmd *cobra.Command, conf *GlobalConfig, generator *tester.TxGenerator) (*tester.Transactor, error) {
+	userNum, err := cmd.Flags().GetInt(flagUserNum)
+	if err != nil {
+		return nil, err
+	}
+
+	sendModeStr, err := cmd.Flags().GetString(flagSendMode)
+	if err != nil {
+		return nil, err
+	}
+	sendMode, err := tester.ParseSendMode(sendModeStr)
+	if err != nil {
+		return nil, err
+	}
+	if sendMode == tester.Rate {
+		return nil, errors.New("the `rate` send mode is not supported by the accounts commands")
+	}
+
+	verifyModeStr, err := cmd.Flags().GetString(flagVerifyMode)
+	if err != nil {
+		return nil, err
+	}
+	verifyMode, err := tester.ParseVerifyMode(verifyModeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return tester.NewTransactor(
+		conf.client,
+		userNum,
+		generator,
+		true,
+		tester.SetSendMode(sendMode),
+		tester.SetVerifyMode(verifyMode),
+	), nil
+}
+
+// printBalances prints the current balance of every account.
+func printBalances(client *ethclient.Client, accounts []common.Address) error {
+	fmt.Println("Output balance statistics:")
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Account", "Balance"})
+	table.SetAutoFormatHeaders(false)
+	for _, account := range accounts {
+		balance, err := client.BalanceAt(context.Background(), account, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch the balance of %s", account)
+		}
+		table.Append([]string{account.Hex(), balance.String()})
+	}
+	table.Render()
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 
+	tester "github.com/dreamer-zq/evm-tester"
 	"github.com/dreamer-zq/evm-tester/simple"
 )
 
@@ -24,16 +25,50 @@ type GlobalConfig struct {
 	url     string
 
 	contract simple.Contract
-	client   *ethclient.Client
+	// newContract returns a new instance of contract
+	newContract func() (simple.Contract, error)
+	manager     *simple.Manager
+	client      *ethclient.Client
 }
 
 func loadGlobalFlags(cmd *cobra.Command, manager *simple.Manager) (*GlobalConfig, error) {
-	url, err := cmd.Flags().GetString(flagURL)
+	conf, err := loadClientFlags(cmd)
 	if err != nil {
 		return nil, err
 	}
+	conf.manager = manager
 
-	contractName, err := cmd.Flags().GetString(flagName)
+	abiPath, err := cmd.Flags().GetString(flagABI)
+	if err != nil {
+		return nil, err
+	}
+	if abiPath != "" {
+		binPath, err := cmd.Flags().GetString(flagBin)
+		if err != nil {
+			return nil, err
+		}
+		conf.newContract = func() (simple.Contract, error) {
+			return simple.LoadABISampler(abiPath, binPath)
+		}
+	} else {
+		contractName, err := cmd.Flags().GetString(flagName)
+		if err != nil {
+			return nil, err
+		}
+		conf.newContract = func() (simple.Contract, error) {
+			return manager.GetContract(contractName), nil
+		}
+	}
+
+	if conf.contract, err = conf.newContract(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// loadClientFlags loads the endpoint and the chain of the commands working without any contract.
+func loadClientFlags(cmd *cobra.Command) (*GlobalConfig, error) {
+	url, err := cmd.Flags().GetString(flagURL)
 	if err != nil {
 		return nil, err
 	}
@@ -43,31 +78,108 @@ func loadGlobalFlags(cmd *cobra.Command, manager *simple.Manager) (*GlobalConfig
 		return nil, err
 	}
 
-	rpcClient, err := rpc.DialOptions(context.Background(), url, rpc.WithHeader("X-Chain", strconv.FormatInt(chainIDInt, 10)))
+	client, err := dial(url, chainIDInt)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to connect to the Ethereum client")
+		return nil, err
 	}
 
 	return &GlobalConfig{
-		chainID:  big.NewInt(chainIDInt),
-		url:      url,
-		client:   ethclient.NewClient(rpcClient),
-		contract: manager.GetContract(contractName),
+		chainID: big.NewInt(chainIDInt),
+		url:     url,
+		client:  client,
 	}, nil
 }
 
+func dial(url string, chainID int64) (*ethclient.Client, error) {
+	rpcClient, err := rpc.DialOptions(context.Background(), url, rpc.WithHeader("X-Chain", strconv.FormatInt(chainID, 10)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to the Ethereum client %s", url)
+	}
+	return ethclient.NewClient(rpcClient), nil
+}
+
+func loadEndpointFlags(cmd *cobra.Command, conf *GlobalConfig) (*tester.EndpointSelector, error) {
+	urls, err := cmd.Flags().GetStringSlice(flagEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	weights, err := cmd.Flags().GetIntSlice(flagEndpointWeights)
+	if err != nil {
+		return nil, err
+	}
+	if len(weights) > 0 && len(weights) != len(urls) {
+		return nil, errors.New("`--endpoint-weights` must have one weight per endpoint")
+	}
+	for i, weight := range weights {
+		if weight <= 0 {
+			return nil, errors.Errorf("invalid weight of the endpoint %s: %d, it must be positive", urls[i], weight)
+		}
+	}
+	// the statistics are reported per url, a duplicate would merge into one row
+	seen := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		if seen[url] {
+			return nil, errors.Errorf("duplicate endpoint: %s", url)
+		}
+		seen[url] = true
+	}
+
+	strategyStr, err := cmd.Flags().GetString(flagEndpointStrategy)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := tester.ParseEndpointStrategy(strategyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]*tester.Endpoint, 0, len(urls))
+	for i, url := range urls {
+		client, err := dial(url, conf.chainID.Int64())
+		if err != nil {
+			return nil, err
+		}
+		endpoint := &tester.Endpoint{
+			URL:    url,
+			Weight: 1,
+			Client: client,
+		}
+		if len(weights) > 0 {
+			endpoint.Weight = weights[i]
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return tester.NewEndpointSelector(endpoints, strategy), nil
+}
+
 // TransactionConfig represents a transaction config
 type TransactionConfig struct {
 	gasLimit  uint64
 	gasFeeCap *big.Int
 	gasTipCap *big.Int
+	gasPricer *tester.GasPricer
 	nonce     int64
 	privKey   *ecdsa.PrivateKey
+	senders   *tester.SenderPool
+
+	txType      tester.TxType
+	accessLists *tester.AccessLists
 
 	contractAddr         common.Address
 	contractMethod       string
 	contractMethodParams []string
 	batchSize            uint64
+
+	workload   string
+	mixPath    string
+	blobs      int
+	blobFeeCap *big.Int
+	recipient  tester.Recipient
+	value      *big.Int
 }
 
 func loadTransactionFlags(cmd *cobra.Command, client *ethclient.Client) (*TransactionConfig, error) {
@@ -121,15 +233,29 @@ func loadTransactionFlags(cmd *cobra.Command, client *ethclient.Client) (*Transa
 		}
 	}
 
+	senderKeys, err := loadSenderKeys(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var senders *tester.SenderPool
+	if len(senderKeys) > 0 {
+		senders, err = tester.NewSenderPool(context.Background(), client, senderKeys)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	method, err := cmd.Flags().GetString(flagContractMethod)
 	if err != nil {
 		return nil, err
 	}
 
-	contractParams, err := cmd.Flags().GetStringSlice(flagContractParams)
+	contractParams, err := cmd.Flags().GetStringArray(flagContractParams)
 	if err != nil {
 		return nil, err
 	}
+	contractParams = simple.SplitParams(contractParams)
 
 	contractAddrStr, err := cmd.Flags().GetString(flagContract)
 	if err != nil {
@@ -137,15 +263,33 @@ func loadTransactionFlags(cmd *cobra.Command, client *ethclient.Client) (*Transa
 	}
 	contractAddr := common.HexToAddress(contractAddrStr)
 
-	return &TransactionConfig{
+	gasPricer, err := loadGasPricer(cmd, client, feeFlag(gasFeeCap), feeFlag(gasTipCap))
+	if err != nil {
+		return nil, err
+	}
+
+	txType, accessLists, err := loadTxTypeFlags(cmd, client)
+	if err != nil {
+		return nil, err
+	}
+
+	txConf := &TransactionConfig{
 		gasLimit:             gasLimit,
-		gasFeeCap:            big.NewInt(gasFeeCap),
-		gasTipCap:            big.NewInt(gasTipCap),
+		gasFeeCap:            feeFlag(gasFeeCap),
+		gasTipCap:            feeFlag(gasTipCap),
+		gasPricer:            gasPricer,
+		txType:               txType,
+		accessLists:          accessLists,
 		nonce:                nonce,
 		privKey:              privKey,
+		senders:              senders,
 		contractAddr:         contractAddr,
 		contractMethod:       method,
 		contractMethodParams: contractParams,
 		batchSize:            batchSize,
-	}, nil
+	}
+	if err := loadWorkloadFlags(cmd, txConf); err != nil {
+		return nil, err
+	}
+	return txConf, nil
 }
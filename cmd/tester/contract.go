@@ -23,10 +23,13 @@ func NewContractCmd() *cobra.Command {
 	contractCmd.AddCommand(MethodsCmd(manager))
 	contractCmd.AddCommand(GentxCmd(manager))
 	contractCmd.AddCommand(StartCmd(manager))
+	contractCmd.AddCommand(DistributeCmd())
 
 	contractCmd.PersistentFlags().String(flagURL, "", "turbo endpoint url")
 	contractCmd.PersistentFlags().String(flagName, "eTicket", "contract name")
 	contractCmd.PersistentFlags().Int64(flagChainID, 0, "turbo chain-id")
+	contractCmd.PersistentFlags().String(flagABI, "", "ABI JSON file (or Hardhat/Foundry artifact) of any contract, replaces --contract-name")
+	contractCmd.PersistentFlags().String(flagBin, "", "hex bytecode file deploying the contract of --abi, the artifact bytecode by default")
 	contractCmd.MarkFlagRequired(flagURL)
 	contractCmd.MarkFlagRequired(flagChainID)
 	return contractCmd
@@ -9,15 +9,18 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/spf13/cobra"
 
+	tester "github.com/dreamer-zq/evm-tester"
 	"github.com/dreamer-zq/evm-tester/simple"
 )
 
 var (
 	flagContractConstructorParams = "contract-constructor-params"
+	flagKeystoreOut               = "keystore-out"
 )
 
 // DeployCmd returns a new instance of the `cobra.Command` struct for the `deploy` command.
@@ -34,10 +37,11 @@ func DeployCmd(manager *simple.Manager) *cobra.Command {
 				return err
 			}
 
-			constructorParams, err := cmd.Flags().GetStringSlice(flagContractConstructorParams)
+			constructorParams, err := cmd.Flags().GetStringArray(flagContractConstructorParams)
 			if err != nil {
 				return err
 			}
+			constructorParams = simple.SplitParams(constructorParams)
 
 			gasLimit, err := cmd.Flags().GetUint64(flagGasLimit)
 			if err != nil {
@@ -54,6 +58,11 @@ func DeployCmd(manager *simple.Manager) *cobra.Command {
 				return err
 			}
 
+			txType, accessLists, err := loadTxTypeFlags(cmd, conf.client)
+			if err != nil {
+				return err
+			}
+
 			privKey, err := cmd.Flags().GetString(flagPrivateKey)
 			if err != nil {
 				return err
@@ -64,28 +73,69 @@ func DeployCmd(manager *simple.Manager) *cobra.Command {
 				return err
 			}
 
-			var senderPrivateKey *ecdsa.PrivateKey
+			storedKeys, err := loadKeystoreKeys(cmd)
+			if err != nil {
+				return err
+			}
+
+			derivedKeys, err := loadMnemonicKeys(cmd)
+			if err != nil {
+				return err
+			}
+
+			keystoreOut, err := cmd.Flags().GetString(flagKeystoreOut)
+			if err != nil {
+				return err
+			}
+
+			var (
+				senderPrivateKey *ecdsa.PrivateKey
+				generated        bool
+			)
 			if privKey != "" {
 				privKey = strings.TrimPrefix(privKey, "0x")
 				senderPrivateKey, err = crypto.HexToECDSA(privKey)
 				if err != nil {
 					return err
 				}
+			} else if len(storedKeys) > 0 {
+				senderPrivateKey = storedKeys[0]
+			} else if len(derivedKeys) > 0 {
+				senderPrivateKey = derivedKeys[0]
 			} else {
 				senderPrivateKey, err = crypto.GenerateKey()
 				if err != nil {
 					return err
 				}
+				generated = true
+			}
+
+			// store the generated key before it is used, so that it is never lost
+			var keystorePath string
+			if generated && keystoreOut != "" {
+				password, err := loadKeystorePassword(cmd)
+				if err != nil {
+					return err
+				}
+				ks := keystore.NewKeyStore(keystoreOut, keystore.StandardScryptN, keystore.StandardScryptP)
+				account, err := ks.ImportECDSA(senderPrivateKey, password)
+				if err != nil {
+					return errors.Wrap(err, "failed to store the deployer key")
+				}
+				keystorePath = account.URL.Path
 			}
 			// Create an authorized transactor and call the store function
 			auth, err := bind.NewKeyedTransactorWithChainID(senderPrivateKey, conf.chainID)
 			if err != nil {
 				return errors.Wrap(err, "failed to create authorized transactor")
 			}
-			auth.GasFeeCap = big.NewInt(gasFeeCap)
-			auth.GasTipCap = big.NewInt(gasTipCap)
+			auth.GasFeeCap = feeFlag(gasFeeCap)
+			auth.GasTipCap = feeFlag(gasTipCap)
 			auth.GasLimit = gasLimit
 			auth.Nonce = big.NewInt(nonce)
+			if err := tester.ApplyTxType(auth, txType, accessLists, conf.client); err != nil {
+				return err
+			}
 			contractAddr, err := conf.contract.Deploy(auth, conf.client, constructorParams)
 			if err != nil {
 				return errors.Wrap(err, "failed to deploy contract")
@@ -93,11 +143,19 @@ func DeployCmd(manager *simple.Manager) *cobra.Command {
 
 			fmt.Println("ContractAddr", contractAddr.Hex())
 			fmt.Println("DeployerAddr", crypto.PubkeyToAddress(senderPrivateKey.PublicKey).Hex())
-			fmt.Println("DeployerPriv", hexutil.Bytes(crypto.FromECDSA(senderPrivateKey)).String())
+			switch {
+			case keystorePath != "":
+				fmt.Println("DeployerKeystore", keystorePath)
+			case generated:
+				fmt.Println("DeployerPriv", hexutil.Bytes(crypto.FromECDSA(senderPrivateKey)).String())
+			}
 			return nil
 		},
 	}
 	addTxFlags(cmd)
-	cmd.Flags().StringSlice(flagContractConstructorParams, []string{}, "the contract constructor params")
+	addKeystoreFlags(cmd)
+	addMnemonicFlags(cmd)
+	cmd.Flags().String(flagKeystoreOut, "", "keystore directory the generated deployer key is written to instead of being printed, encrypted with the keystore password")
+	cmd.Flags().StringArray(flagContractConstructorParams, []string{}, "the contract constructor params, comma separated, arrays and tuples in JSON")
 	return cmd
 }
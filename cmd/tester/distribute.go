@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	tester "github.com/dreamer-zq/evm-tester"
+	"github.com/dreamer-zq/evm-tester/simple"
+	"github.com/dreamer-zq/evm-tester/simple/gen"
+)
+
+var flagAllowance = "allowance"
+
+// DistributeCmd returns a cobra command distributing the tokens of an ERC20Token contract from a holder account.
+//
+// Every account receives `--amount` tokens and, with `--allowance`, may spend
+// that many tokens of the holder with transferFrom. The token balances and
+// allowances are printed at the end.
+func DistributeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "distribute",
+		Short: "Send ERC-20 tokens from a holder account to the test accounts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := loadClientFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			holder, err := loadPrivateKey(cmd)
+			if err != nil {
+				return err
+			}
+			if holder == nil {
+				return errors.Errorf("`--%s` or `--%s` of the holder account is required", flagPrivateKey, flagPrivateKeystore)
+			}
+
+			contractAddrStr, err := cmd.Flags().GetString(flagContract)
+			if err != nil {
+				return err
+			}
+			if !common.IsHexAddress(contractAddrStr) {
+				return errors.Errorf("invalid contract address: %s", contractAddrStr)
+			}
+			contractAddr := common.HexToAddress(contractAddrStr)
+
+			recipients, err := loadRecipients(cmd)
+			if err != nil {
+				return err
+			}
+			if len(recipients) == 0 {
+				return errors.Errorf("no account to distribute to, use `--%s`, `--%s`, `--%s` or `--%s`", flagRecipients, flagSenderKeys, flagKeystore, flagMnemonic)
+			}
+
+			amount, err := loadTokenAmount(cmd, flagAmount)
+			if err != nil {
+				return err
+			}
+
+			allowance, err := loadTokenAmount(cmd, flagAllowance)
+			if err != nil {
+				return err
+			}
+
+			token := &simple.ERC20Sampler{}
+			token.SetContractAddr(contractAddr)
+			createTx, err := token.DistributeTxBuilder(conf.client, recipients, amount, allowance)
+			if err != nil {
+				return err
+			}
+
+			senders, err := tester.NewSenderPool(context.Background(), conf.client, []*ecdsa.PrivateKey{holder})
+			if err != nil {
+				return err
+			}
+
+			generator, err := getAccountsGenerator(cmd, conf, senders, createTx)
+			if err != nil {
+				return err
+			}
+
+			transactor, err := getAccountsTransactor(cmd, conf, generator)
+			if err != nil {
+				return err
+			}
+			transactor.Run()
+			return printTokenBalances(conf, contractAddr, crypto.PubkeyToAddress(holder.PublicKey), recipients)
+		},
+	}
+	addAccountsTxFlags(cmd)
+	cmd.Flags().String(flagContract, "", "address of the ERC20Token contract, the tokens are sent by --private-key")
+	cmd.Flags().StringSlice(flagRecipients, []string{}, "addresses of the accounts receiving the tokens")
+	cmd.Flags().String(flagAmount, "", "tokens sent to every account, in the smallest unit of the token")
+	cmd.Flags().String(flagAllowance, "0", "tokens of the holder every account may spend with transferFrom, 0 approves nothing")
+	cmd.Flags().String(flagSenderKeys, "", "file of the private keys of the accounts receiving the tokens, one per line")
+	addKeystoreFlags(cmd)
+	addPrivateKeystoreFlag(cmd, "holder")
+	addMnemonicFlags(cmd)
+	cmd.MarkFlagRequired(flagContract)
+	cmd.MarkFlagRequired(flagAmount)
+	return cmd
+}
+
+// loadTokenAmount loads a token amount flag, in the smallest unit of the token.
+func loadTokenAmount(cmd *cobra.Command, flag string) (*big.Int, error) {
+	amountStr, err := cmd.Flags().GetString(flag)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := new(big.Int).SetString(amountStr, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, errors.Errorf("invalid %s: %s", flag, amountStr)
+	}
+	return amount, nil
+}
+
+// printTokenBalances prints the token balance of every account and the tokens of the holder it may spend.
+func printTokenBalances(conf *GlobalConfig, contractAddr, holder common.Address, accounts []common.Address) error {
+	token, err := gen.NewERC20Token(contractAddr, conf.client)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Output token balance statistics:")
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Account", "Balance", "Allowance"})
+	table.SetAutoFormatHeaders(false)
+	for _, account := range append([]common.Address{holder}, accounts...) {
+		balance, err := token.BalanceOf(&bind.CallOpts{}, account)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch the token balance of %s", account)
+		}
+		allowance, err := token.Allowance(&bind.CallOpts{}, holder, account)
+		if err != nil {
+			return errors.Wrapf(err, "failed to fetch the allowance of %s", account)
+		}
+		table.Append([]string{account.Hex(), balance.String(), allowance.String()})
+	}
+	table.Render()
+	return nil
+}
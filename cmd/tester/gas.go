@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	tester "github.com/dreamer-zq/evm-tester"
+)
+
+var (
+	flagGasStrategy   = "gas-strategy"
+	flagGasPercentile = "gas-percentile"
+	flagGasBlocks     = "gas-history-blocks"
+	flagGasTipMin     = "gas-tip-min"
+	flagGasTipMax     = "gas-tip-max"
+	flagGasRefresh    = "gas-refresh"
+)
+
+func addGasFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagGasStrategy, "fixed", "gas pricing strategy, `fixed` (`--gas-fee-cap` and `--gas-tip-cap`), `oracle` (suggested tip cap and base fee), `fee-history` (percentile of the tips of the latest blocks) or `random` (tip cap drawn within `--gas-tip-min` and `--gas-tip-max`)")
+	cmd.Flags().Float64(flagGasPercentile, 50, "percentile of the tips paid in the latest blocks, for the fee-history strategy")
+	cmd.Flags().Uint64(flagGasBlocks, 20, "number of latest blocks the tips are taken from, for the fee-history strategy")
+	cmd.Flags().Int64(flagGasTipMin, 0, "lowest tip cap of the random strategy")
+	cmd.Flags().Int64(flagGasTipMax, 0, "highest tip cap of the random strategy")
+	cmd.Flags().Duration(flagGasRefresh, 12*time.Second, "time between two refreshes of the gas prices during a run, 0 prices once at start")
+}
+
+// loadGasPricer creates the gas pricer of the generator, `--gas-fee-cap` caps
+// the fee cap of the strategies other than `fixed`.
+func loadGasPricer(cmd *cobra.Command, client *ethclient.Client, gasFeeCap, gasTipCap *big.Int) (*tester.GasPricer, error) {
+	strategyStr, err := cmd.Flags().GetString(flagGasStrategy)
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := tester.ParseGasStrategy(strategyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	percentile, err := cmd.Flags().GetFloat64(flagGasPercentile)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := cmd.Flags().GetUint64(flagGasBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	minTipCap, err := cmd.Flags().GetInt64(flagGasTipMin)
+	if err != nil {
+		return nil, err
+	}
+
+	maxTipCap, err := cmd.Flags().GetInt64(flagGasTipMax)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := cmd.Flags().GetDuration(flagGasRefresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return tester.NewGasPricer(client, tester.GasConfig{
+		Strategy:   strategy,
+		GasFeeCap:  gasFeeCap,
+		GasTipCap:  gasTipCap,
+		MinTipCap:  big.NewInt(minTipCap),
+		MaxTipCap:  big.NewInt(maxTipCap),
+		Percentile: percentile,
+		Blocks:     blocks,
+		Refresh:    refresh,
+	})
+}
+
+// feeFlag converts the value of a fee flag, 0 is nil so that the fee comes
+// from the gas price oracle.
+func feeFlag(fee int64) *big.Int {
+	if fee == 0 {
+		return nil
+	}
+	return big.NewInt(fee)
+}
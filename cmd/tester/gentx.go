@@ -43,11 +43,15 @@ func GentxCmd(manager *simple.Manager) *cobra.Command {
 				return err
 			}
 
-			generator, err := getGenerator(conf, cmd)
+			generator, txConf, err := getGenerator(conf, cmd)
 			if err != nil {
 				return err
 			}
 
+			if err := runPreflight(cmd, conf, txConf, int64(txConf.batchSize)); err != nil {
+				return err
+			}
+
 			data, _, err := generator.Run()
 			if err != nil {
 				return err
@@ -61,20 +65,20 @@ func GentxCmd(manager *simple.Manager) *cobra.Command {
 	return cmd
 }
 
-func getGenerator(conf *GlobalConfig, cmd *cobra.Command) (*tester.TxGenerator, error) {
+func getGenerator(conf *GlobalConfig, cmd *cobra.Command) (*tester.TxGenerator, *TransactionConfig, error) {
 	maxThreads, err := cmd.Flags().GetInt(flagMaxThreads)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	txConf, err := loadTransactionFlags(cmd, conf.client)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	concurrent, err := cmd.Flags().GetBool(flagConcurrent)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	opts := []tester.Option{
@@ -82,15 +86,23 @@ func getGenerator(conf *GlobalConfig, cmd *cobra.Command) (*tester.TxGenerator,
 		tester.SetGasLimit(txConf.gasLimit),
 		tester.SetGasFeeCap(txConf.gasFeeCap),
 		tester.SetGasTipCap(txConf.gasTipCap),
+		tester.SetGasPricer(txConf.gasPricer),
+		tester.SetTxType(txConf.txType, txConf.accessLists, conf.client),
 		tester.SetPrivKey(txConf.privKey),
+		tester.SetSenderPool(txConf.senders),
 		tester.SetNonce(txConf.nonce),
 		tester.SetConcurrent(concurrent),
 	}
 
-	conf.contract.SetContractAddr(txConf.contractAddr)
-	txBuilrder, err := conf.contract.GenTxBuilder(conf.client, txConf.contractMethod, txConf.contractMethodParams)
-	if err != nil {
-		return nil, err
+	var txBuilrder tester.CreateTx
+	if txConf.mixPath != "" {
+		mix, err := loadMix(conf, txConf.mixPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, tester.SetMix(mix))
+	} else if txBuilrder, err = newCreateTx(conf, txConf); err != nil {
+		return nil, nil, err
 	}
 
 	return tester.NewTxGenerator(
@@ -98,7 +110,7 @@ func getGenerator(conf *GlobalConfig, cmd *cobra.Command) (*tester.TxGenerator,
 		txBuilrder,
 		tester.NewPool(maxThreads, "gentx"),
 		opts...,
-	), nil
+	), txConf, nil
 }
 
 func addGenTxFlags(cmd *cobra.Command) {
@@ -110,20 +122,24 @@ func addGenTxFlags(cmd *cobra.Command) {
 func addTxFlags(cmd *cobra.Command) {
 	cmd.Flags().String(flagPrivateKey, "", "send the account private key for the transaction")
 	cmd.Flags().Int64(flagNonce, 0, "user's nonce")
-	cmd.Flags().Int64(flagGasFeeCap, 0, "gas fee cap to use for the 1559 transaction execution (nil = gas price oracle,fetch from chain)")
-	cmd.Flags().Int64(flagGasTipCap, 0, "gas priority fee cap to use for the 1559 transaction execution (nil = gas price oracle,fetch from chain)")
+	cmd.Flags().Int64(flagGasFeeCap, 0, "gas fee cap to use for the 1559 transaction execution (0 = gas price oracle,fetch from chain)")
+	cmd.Flags().Int64(flagGasTipCap, 0, "gas priority fee cap to use for the 1559 transaction execution (0 = gas price oracle,fetch from chain)")
 	cmd.Flags().Uint64(flagGasLimit, 0, "gas limit to set for the transaction execution (0 = estimate,fetch from chain)")
+	addTxTypeFlags(cmd)
 }
 
 func addSendTxFlags(cmd *cobra.Command) {
 	addTxFlags(cmd)
+	addGasFlags(cmd)
 	cmd.Flags().Uint64(flagBatchSize, 10, "number of transactions per batch")
 	cmd.Flags().Bool(flagConcurrent, false, "whether to use concurrent mode,the number of concurrencies is the same as `data-count`")
 	cmd.Flags().Int(flagMaxThreads, 100, "maximum number of threads")
+	cmd.Flags().String(flagSenderKeys, "", "file of the sender accounts private keys, one per line, the transactions are spread over the accounts")
+	addKeystoreFlags(cmd)
+	addMnemonicFlags(cmd)
+	cmd.Flags().String(flagPreflight, "warn", "pre-flight check of the chain and of the sender balances against the worst-case cost, `off`, `warn` or `strict` (refuses to start, requires `--gas-limit`)")
 	cmd.Flags().String(flagContractMethod, "", "the contract method name being tested")
-	cmd.Flags().StringSlice(flagContractParams, []string{}, "the contract method params being tested")
+	cmd.Flags().StringArray(flagContractParams, []string{}, "the contract method params being tested, comma separated, arrays and tuples in JSON")
 	cmd.Flags().String(flagContract, "", "the contract address being tested")
-
-	cmd.MarkFlagRequired(flagContract)
-	cmd.MarkFlagRequired(flagContractMethod)
+	addWorkloadFlags(cmd)
 }
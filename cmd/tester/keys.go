@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	tester "github.com/dreamer-zq/evm-tester"
+)
+
+var (
+	flagSenderKeys = "sender-keys"
+	flagMnemonic   = "mnemonic"
+	flagHDPath     = "hd-path"
+	flagHDIndex    = "hd-index"
+	flagHDCount    = "hd-count"
+
+	flagKeystore        = "keystore"
+	flagPasswordFile    = "password-file"
+	flagPrivateKeystore = "private-keystore"
+)
+
+// envKeystorePassword is the environment variable the keystore password is read
+// from when no password file is given.
+const envKeystorePassword = "EVM_TESTER_KEYSTORE_PASSWORD"
+
+func addMnemonicFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagMnemonic, "", "BIP-39 mnemonic the account keys are derived from")
+	cmd.Flags().String(flagHDPath, tester.DefaultHDPath, "BIP-44 derivation path, the account index is appended to it")
+	cmd.Flags().Uint32(flagHDIndex, 0, "index of the first account derived from the mnemonic")
+	cmd.Flags().Uint32(flagHDCount, 1, "number of accounts derived from the mnemonic")
+}
+
+func addKeystoreFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagKeystore, "", "JSON keystore file or directory the account keys are loaded from")
+	cmd.Flags().String(flagPasswordFile, "", "file of the keystore password, the "+envKeystorePassword+" environment variable is used when empty")
+}
+
+// addPrivateKeystoreFlag adds the keystore of the single account the commands
+// load with loadPrivateKey, next to the keystore of the other accounts.
+func addPrivateKeystoreFlag(cmd *cobra.Command, account string) {
+	cmd.Flags().String(flagPrivateKeystore, "", "JSON keystore file of the "+account+" account, replaces --"+flagPrivateKey+", decrypted with the keystore password")
+}
+
+// loadSenderKeys loads the private keys of the sender accounts, from the keys
+// file first, then from the keystore and then from the mnemonic.
+//
+// It returns no key when no sender account is configured.
+func loadSenderKeys(cmd *cobra.Command) ([]*ecdsa.PrivateKey, error) {
+	path, err := cmd.Flags().GetString(flagSenderKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []*ecdsa.PrivateKey
+	if path != "" {
+		if keys, err = loadKeysFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	stored, err := loadKeystoreKeys(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	derived, err := loadMnemonicKeys(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(keys, stored...), derived...), nil
+}
+
+// loadKeystoreKeys decrypts the account keys of the keystore file, or of every
+// file of the keystore directory.
+//
+// It returns no key when no keystore is configured.
+func loadKeystoreKeys(cmd *cobra.Command) ([]*ecdsa.PrivateKey, error) {
+	path, err := cmd.Flags().GetString(flagKeystore)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	return decryptKeystore(cmd, path)
+}
+
+// decryptKeystore decrypts the account keys of the keystore file, or of every
+// file of the keystore directory, with the keystore password.
+func decryptKeystore(cmd *cobra.Command, path string) ([]*ecdsa.PrivateKey, error) {
+	password, err := loadKeystorePassword(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			// skip the editor backups and the hidden files, as go-ethereum does
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || strings.HasSuffix(entry.Name(), "~") {
+				continue
+			}
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+
+	keys := make([]*ecdsa.PrivateKey, 0, len(files))
+	for _, file := range files {
+		keyJSON, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		key, err := keystore.DecryptKey(keyJSON, password)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt keystore %s", file)
+		}
+		keys = append(keys, key.PrivateKey)
+	}
+	return keys, nil
+}
+
+// loadKeystorePassword reads the keystore password from the password file, or
+// from the environment when no file is given.
+func loadKeystorePassword(cmd *cobra.Command) (string, error) {
+	path, err := cmd.Flags().GetString(flagPasswordFile)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		password, ok := os.LookupEnv(envKeystorePassword)
+		if !ok {
+			return "", errors.Errorf("keystore password is missing, use `--%s` or `%s`", flagPasswordFile, envKeystorePassword)
+		}
+		return password, nil
+	}
+
+	password, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	// the password is the first line of the file, like geth --password
+	return strings.TrimRight(strings.SplitN(string(password), "\n", 2)[0], "\r"), nil
+}
+
+// loadMnemonicKeys derives the account keys of the configured index range from the mnemonic.
+//
+// It returns no key when no mnemonic is configured.
+func loadMnemonicKeys(cmd *cobra.Command) ([]*ecdsa.PrivateKey, error) {
+	mnemonic, err := cmd.Flags().GetString(flagMnemonic)
+	if err != nil {
+		return nil, err
+	}
+	if mnemonic == "" {
+		return nil, nil
+	}
+
+	path, err := cmd.Flags().GetString(flagHDPath)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := cmd.Flags().GetUint32(flagHDIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := cmd.Flags().GetUint32(flagHDCount)
+	if err != nil {
+		return nil, err
+	}
+	return tester.DeriveKeys(mnemonic, "", path, index, count)
+}
+
+// loadKeysFile loads hex encoded private keys from a file, one per line.
+//
+// Empty lines and lines starting with `#` are ignored.
+func loadKeysFile(path string) ([]*ecdsa.PrivateKey, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var keys []*ecdsa.PrivateKey
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, err := crypto.HexToECDSA(strings.TrimPrefix(text, "0x"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid private key at %s:%d", path, line)
+		}
+		keys = append(keys, key)
+	}
+	return keys, scanner.Err()
+}
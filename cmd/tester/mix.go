@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	tester "github.com/dreamer-zq/evm-tester"
+	"github.com/dreamer-zq/evm-tester/simple"
+)
+
+// mixEntryConfig is an entry of the `--mix` file, eg:
+//
+//	[
+//	  {"name": "transfer", "weight": 70, "contract-name": "erc20", "contract": "0x...", "method": "transfer", "params": ["random", "1"]},
+//	  {"name": "redeem", "weight": 20, "contract-name": "ticket", "contract": "0x...", "method": "redeem", "params": ["0x..."]},
+//	  {"name": "batch", "weight": 10, "abi": "./TicketGame.abi", "contract": "0x...", "method": "batchRedeem", "params": [["0x..."], ["uri"]]}
+//	]
+//
+// The sampler of an entry is `abi`, then `contract-name`, then the sampler of
+// the command, the name defaults to the method.
+type mixEntryConfig struct {
+	Name         string            `json:"name"`
+	Weight       int               `json:"weight"`
+	ContractName string            `json:"contract-name"`
+	ABI          string            `json:"abi"`
+	Contract     string            `json:"contract"`
+	Method       string            `json:"method"`
+	Params       []json.RawMessage `json:"params"`
+}
+
+// loadMix loads the mix of the `--mix` file, every entry is bound to its contract.
+func loadMix(conf *GlobalConfig, path string) (*tester.Mix, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var configs []mixEntryConfig
+	if err := json.Unmarshal(bz, &configs); err != nil {
+		return nil, errors.Wrapf(err, "invalid mix file %s", path)
+	}
+
+	entries := make([]*tester.MixEntry, 0, len(configs))
+	for i, config := range configs {
+		if config.Name == "" {
+			config.Name = config.Method
+		}
+		if !common.IsHexAddress(config.Contract) || config.Method == "" {
+			return nil, errors.Errorf("mix entry %d requires a `contract` and a `method`", i)
+		}
+
+		contract, err := mixSampler(conf, config)
+		if err != nil {
+			return nil, errors.Wrapf(err, "mix entry %s", config.Name)
+		}
+
+		params := make([]string, 0, len(config.Params))
+		for _, param := range config.Params {
+			params = append(params, mixParam(param))
+		}
+
+		contract.SetContractAddr(common.HexToAddress(config.Contract))
+		createTx, err := contract.GenTxBuilder(conf.client, config.Method, params)
+		if err != nil {
+			return nil, errors.Wrapf(err, "mix entry %s", config.Name)
+		}
+		entries = append(entries, &tester.MixEntry{
+			Name:     config.Name,
+			Weight:   config.Weight,
+			CreateTx: createTx,
+		})
+	}
+	return tester.NewMix(entries)
+}
+
+// mixSampler returns a new sampler of a mix entry, the entries never share a
+// sampler bound to another contract.
+func mixSampler(conf *GlobalConfig, config mixEntryConfig) (simple.Contract, error) {
+	switch {
+	case config.ABI != "":
+		return simple.LoadABISampler(config.ABI, "")
+	case config.ContractName != "":
+		if !slices.Contains(conf.manager.ListContracts(), config.ContractName) {
+			return nil, errors.Errorf("invalid contract name: %s", config.ContractName)
+		}
+		return conf.manager.GetContract(config.ContractName), nil
+	default:
+		return conf.newContract()
+	}
+}
+
+// mixParam returns a JSON string param unquoted, and the arrays, objects and
+// numbers as they are, in the format of `--contract-method-params`.
+func mixParam(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
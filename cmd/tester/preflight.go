@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	tester "github.com/dreamer-zq/evm-tester"
+)
+
+var (
+	flagPreflight = "preflight"
+)
+
+// runPreflight checks that the senders of the generator can afford txCount
+// transactions, txCount is 0 when the run is unbounded.
+//
+// A `--mix` is checked as the contract workload: every entry shares the gas
+// limit, the fee caps and the zero value of the run, so the costs are the same
+// whichever entry is picked.
+//
+// It returns an error only in the `strict` mode, when a problem is found or
+// when the gas limit is left to the estimation.
+func runPreflight(cmd *cobra.Command, conf *GlobalConfig, txConf *TransactionConfig, txCount int64) error {
+	modeStr, err := cmd.Flags().GetString(flagPreflight)
+	if err != nil {
+		return err
+	}
+	mode, err := tester.ParsePreflightMode(modeStr)
+	if err != nil {
+		return err
+	}
+	if mode == tester.PreflightOff {
+		return nil
+	}
+	if mode == tester.PreflightStrict && txConf.gasLimit == 0 {
+		// the estimated gas is unknown until the transactions are built
+		return errors.Errorf("the `%s` preflight requires `--%s`", tester.PreflightStrict, flagGasLimit)
+	}
+
+	concurrent, err := cmd.Flags().GetBool(flagConcurrent)
+	if err != nil {
+		return err
+	}
+
+	preflight := &tester.Preflight{
+		ChainID:   conf.chainID,
+		TxCount:   txCount,
+		BatchSize: txConf.batchSize,
+		GasLimit:  txConf.gasLimit,
+		GasFeeCap: txConf.gasFeeCap,
+		GasTipCap: txConf.gasTipCap,
+		Value:     txConf.value,
+	}
+	if txConf.workload == workloadBlob {
+		preflight.BlobGas = uint64(txConf.blobs) * params.BlobTxBlobGasPerBlob
+		preflight.BlobFeeCap = txConf.blobFeeCap
+	}
+	if txConf.gasPricer != nil {
+		preflight.GasFeeCap, preflight.GasTipCap = txConf.gasPricer.Ceiling()
+	}
+	// the same precedence as the generator
+	switch {
+	case concurrent:
+		preflight.RandomSenders = true
+	case txConf.senders != nil:
+		for _, sender := range txConf.senders.Senders() {
+			preflight.Senders = append(preflight.Senders, sender.Address)
+		}
+	case txConf.privKey != nil:
+		preflight.Senders = []common.Address{crypto.PubkeyToAddress(txConf.privKey.PublicKey)}
+	default:
+		preflight.RandomSenders = true
+	}
+
+	report, err := preflight.Check(context.Background(), conf.client)
+	if err != nil {
+		return err
+	}
+	report.Print()
+	if !report.OK() && mode == tester.PreflightStrict {
+		return errors.New("preflight check failed, see the problems above")
+	}
+	return nil
+}
+
+// plannedTxCount returns the number of transactions a run sends at most, or 0
+// when it is unbounded.
+func plannedTxCount(
+	batchSize uint64,
+	totalBatch int64,
+	sendMode tester.SendMode,
+	sendRate float64,
+	runPeriod time.Duration,
+	profile tester.LoadProfile,
+) int64 {
+	var count int64
+	if totalBatch > 0 {
+		count = totalBatch * int64(batchSize)
+	}
+	if sendMode != tester.Rate {
+		return count
+	}
+
+	var rated float64
+	switch {
+	case len(profile) > 0:
+		// a linear stage never goes above its own rate
+		for _, stage := range profile {
+			rated += math.Max(stage.Rate, sendRate) * stage.Duration.Seconds()
+		}
+	case runPeriod > 0:
+		rated = sendRate * runPeriod.Seconds()
+	default:
+		return count
+	}
+	if count == 0 || int64(math.Ceil(rated)) < count {
+		count = int64(math.Ceil(rated))
+	}
+	return count
+}
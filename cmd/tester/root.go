@@ -9,6 +9,8 @@ var (
 	flagURL     = "url"
 	flagChainID = "chain-id"
 	flagName    = "contract-name"
+	flagABI     = "abi"
+	flagBin     = "bin"
 )
 
 // NewRootCmd returns a new instance of the cobra.Command struct.
@@ -24,5 +26,6 @@ func NewRootCmd() *cobra.Command {
 	manager := simple.NewManager()
 	rootCmd.AddCommand(ListCmd(manager))
 	rootCmd.AddCommand(NewContractCmd())
+	rootCmd.AddCommand(NewAccountsCmd())
 	return rootCmd
 }
\ No newline at end of file
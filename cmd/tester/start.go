@@ -3,6 +3,7 @@ package cmd
 import (
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	tester "github.com/dreamer-zq/evm-tester"
@@ -16,6 +17,20 @@ var (
 	flagSegment      = "run-segment"
 	flagSendMode     = "send-mode"
 	flagEnableVerify = "enable-verify"
+	flagSendRate     = "send-rate"
+	flagLoadProfile  = "load-profile"
+	flagTimeSeries   = "timeseries-output"
+	flagMetricsAddr  = "metrics-addr"
+	flagWatchBlocks  = "watch-blocks"
+	flagVerifyMode   = "verify-mode"
+	flagNonceResync  = "nonce-resync"
+	flagStuckPolicy  = "stuck-policy"
+	flagStuckAfter   = "stuck-after"
+	flagFeeBump      = "fee-bump"
+
+	flagEndpoints        = "endpoints"
+	flagEndpointWeights  = "endpoint-weights"
+	flagEndpointStrategy = "endpoint-strategy"
 )
 
 // StartCmd generates a cobra command for sending transaction.
@@ -39,7 +54,7 @@ func StartCmd(manager *simple.Manager) *cobra.Command {
 				return err
 			}
 
-			generator, err := getGenerator(conf, cmd)
+			generator, txConf, err := getGenerator(conf, cmd)
 			if err != nil {
 				return err
 			}
@@ -58,11 +73,36 @@ func StartCmd(manager *simple.Manager) *cobra.Command {
 				return err
 			}
 
+			sendRate, err := cmd.Flags().GetFloat64(flagSendRate)
+			if err != nil {
+				return err
+			}
+
+			stages, err := cmd.Flags().GetStringArray(flagLoadProfile)
+			if err != nil {
+				return err
+			}
+			profile, err := tester.ParseLoadProfile(stages)
+			if err != nil {
+				return err
+			}
+			if profile.HasRate() && sendMode != tester.Rate {
+				return errors.New("load stages with a `rate` require the `rate` send mode")
+			}
+			if sendMode == tester.Rate && sendRate <= 0 && !profile.HasRate() {
+				return errors.New("`--send-rate` must be greater than 0 in `rate` send mode")
+			}
+
 			runPeriod, err := cmd.Flags().GetDuration(flagRunPeriod)
 			if err != nil {
 				return err
 			}
 
+			txCount := plannedTxCount(txConf.batchSize, totalBatch, sendMode, sendRate, runPeriod, profile)
+			if err := runPreflight(cmd, conf, txConf, txCount); err != nil {
+				return err
+			}
+
 			var endTime time.Time
 			if runPeriod > 0 {
 				endTime = time.Now().Add(runPeriod)
@@ -78,6 +118,84 @@ func StartCmd(manager *simple.Manager) *cobra.Command {
 				return err
 			}
 
+			timeSeries, err := cmd.Flags().GetString(flagTimeSeries)
+			if err != nil {
+				return err
+			}
+
+			metricsAddr, err := cmd.Flags().GetString(flagMetricsAddr)
+			if err != nil {
+				return err
+			}
+
+			verifyModeStr, err := cmd.Flags().GetString(flagVerifyMode)
+			if err != nil {
+				return err
+			}
+			verifyMode, err := tester.ParseVerifyMode(verifyModeStr)
+			if err != nil {
+				return err
+			}
+
+			watchBlocks, err := cmd.Flags().GetBool(flagWatchBlocks)
+			if err != nil {
+				return err
+			}
+
+			endpoints, err := loadEndpointFlags(cmd, conf)
+			if err != nil {
+				return err
+			}
+
+			nonceResync, err := cmd.Flags().GetBool(flagNonceResync)
+			if err != nil {
+				return err
+			}
+			if nonceResync && (sendMode == tester.Batch || sendMode == tester.Rate) {
+				return errors.Errorf("`--%s` is not supported in the `%s` send mode", flagNonceResync, sendMode)
+			}
+
+			stuckPolicyStr, err := cmd.Flags().GetString(flagStuckPolicy)
+			if err != nil {
+				return err
+			}
+			stuckPolicy, err := tester.ParseStuckPolicy(stuckPolicyStr)
+			if err != nil {
+				return err
+			}
+			if stuckPolicy != tester.StuckOff && !enableVerify {
+				return errors.Errorf("`--%s` requires `--%s`", flagStuckPolicy, flagEnableVerify)
+			}
+			concurrent, err := cmd.Flags().GetBool(flagConcurrent)
+			if err != nil {
+				return err
+			}
+			// the replacements are signed with the keys of the senders, the
+			// concurrent mode signs with random keys
+			if stuckPolicy != tester.StuckOff && (concurrent || txConf.privKey == nil && txConf.senders == nil) {
+				return errors.Errorf("`--%s` requires the keys of the senders, `--%s` or a sender pool", flagStuckPolicy, flagPrivateKey)
+			}
+
+			stuckAfter, err := cmd.Flags().GetDuration(flagStuckAfter)
+			if err != nil {
+				return err
+			}
+
+			feeBump, err := cmd.Flags().GetInt64(flagFeeBump)
+			if err != nil {
+				return err
+			}
+			if feeBump < 10 {
+				return errors.Errorf("`--%s` must be at least 10, the minimum replacement bump of the nodes", flagFeeBump)
+			}
+
+			var metrics *tester.Metrics
+			if metricsAddr != "" {
+				if metrics, err = tester.NewMetrics(metricsAddr); err != nil {
+					return err
+				}
+			}
+
 			transactor := tester.NewTransactor(
 				conf.client,
 				userNum,
@@ -86,7 +204,19 @@ func StartCmd(manager *simple.Manager) *cobra.Command {
 				tester.SetTotalBatch(totalBatch),
 				tester.SetEndTime(endTime),
 				tester.SetSendMode(sendMode),
+				tester.SetTargetRate(sendRate),
+				tester.SetLoadProfile(profile),
+				tester.SetTimeSeriesOutput(timeSeries),
+				tester.SetMetrics(metrics),
+				tester.SetWatchBlocks(watchBlocks),
+				tester.SetVerifyMode(verifyMode),
+				tester.SetEndpoints(endpoints),
+				tester.SetNonceResync(nonceResync),
+				tester.SetStuckPolicy(stuckPolicy, stuckAfter, feeBump),
 			)
+			go txConf.gasPricer.Start()
+			defer txConf.gasPricer.Stop()
+
 			transactor.Run()
 			return nil
 		},
@@ -96,7 +226,20 @@ func StartCmd(manager *simple.Manager) *cobra.Command {
 	cmd.Flags().Duration(flagRunPeriod, 0, "stress test execution time,eg: 5m")
 	cmd.Flags().Bool(flagSegment, false, "whether to enable segmented statistics requires run-total-batch to be greater than 1")
 	cmd.Flags().Bool(flagEnableVerify, false, "whether to enable verification(transaction)")
+	cmd.Flags().String(flagVerifyMode, "receipt", "verification mode, `receipt` polls every transaction receipt, `block` follows the new blocks (subscribes on websocket endpoints)")
 	cmd.Flags().Int64(flagTotalBatch, 0, "total production batches, and `--run-period`, choose one of the two,`totalTxs = totalBatch * count`")
-	cmd.Flags().String(flagSendMode, "parallel", "transaction sending mode, `oneByOne`,`parallel` ,`segment` or `batch`")
+	cmd.Flags().String(flagSendMode, "parallel", "transaction sending mode, `oneByOne`,`parallel` ,`segment`, `batch` or `rate`")
+	cmd.Flags().Float64(flagSendRate, 0, "target transactions per second in `rate` send mode, eg: 2000")
+	cmd.Flags().String(flagTimeSeries, "", "per-second metrics output path, `.jsonl` for JSON lines and CSV otherwise, eg: ./timeseries.csv")
+	cmd.Flags().String(flagMetricsAddr, "", "listen address of the prometheus metrics endpoint, eg: :9090")
+	cmd.Flags().Bool(flagNonceResync, false, "whether to re-sign and resend the transactions of a sender when its nonces go out of sync, the transactions of a sender are then sent one by one")
+	cmd.Flags().String(flagStuckPolicy, "off", "replacement of the transactions still pending after --stuck-after, `off`, `speedup` (same transaction with higher fees) or `cancel` (zero-value transfer to the sender), requires --enable-verify")
+	cmd.Flags().Duration(flagStuckAfter, time.Minute, "time a transaction stays pending before it is replaced, the receipt verify mode checks it every 10s")
+	cmd.Flags().Int64(flagFeeBump, 10, "fee increase of a replacement transaction in percent, at least 10")
+	cmd.Flags().Bool(flagWatchBlocks, false, "whether to follow the new blocks and report the on-chain throughput")
+	cmd.Flags().StringSlice(flagEndpoints, []string{}, "endpoint urls the transactions are sent to, `--url` is used when empty")
+	cmd.Flags().IntSlice(flagEndpointWeights, []int{}, "weight of every endpoint for the `weighted` strategy, in the order of `--endpoints`")
+	cmd.Flags().String(flagEndpointStrategy, "round-robin", "the way the transactions are spread over the endpoints, `round-robin`, `weighted` or `sticky`")
+	cmd.Flags().StringArray(flagLoadProfile, []string{}, "load stage, repeat the flag for each stage in order and it replaces `--run-period`, eg: `name=ramp:duration=1m:users=50:shape=linear`")
 	return cmd
 }
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	tester "github.com/dreamer-zq/evm-tester"
+)
+
+var (
+	flagTxType           = "tx-type"
+	flagCreateAccessList = "create-access-list"
+)
+
+func addTxTypeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagTxType, "dynamic-fee", "transaction type, `legacy` (gas price), `access-list` (EIP-2930) or `dynamic-fee` (EIP-1559, legacy on the chains without base fee), the gas price of the first two is `--gas-fee-cap`")
+	cmd.Flags().Bool(flagCreateAccessList, false, "whether to attach the access list of every contract method, created once per method with eth_createAccessList")
+}
+
+// loadTxTypeFlags loads the type of the transactions and their access lists,
+// the access lists are nil when they are not created.
+func loadTxTypeFlags(cmd *cobra.Command, client *ethclient.Client) (tester.TxType, *tester.AccessLists, error) {
+	txTypeStr, err := cmd.Flags().GetString(flagTxType)
+	if err != nil {
+		return "", nil, err
+	}
+	txType, err := tester.ParseTxType(txTypeStr)
+	if err != nil {
+		return "", nil, err
+	}
+
+	createAccessList, err := cmd.Flags().GetBool(flagCreateAccessList)
+	if err != nil {
+		return "", nil, err
+	}
+	if !createAccessList {
+		return txType, nil, nil
+	}
+	if txType == tester.TxLegacy {
+		return "", nil, errors.Errorf("`--%s` requires a transaction type with an access list", flagCreateAccessList)
+	}
+	return txType, tester.NewAccessLists(client), nil
+}
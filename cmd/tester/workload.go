@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	tester "github.com/dreamer-zq/evm-tester"
+)
+
+var (
+	flagWorkload   = "workload"
+	flagMix        = "mix"
+	flagBlobCount  = "blob-count"
+	flagBlobFeeCap = "blob-fee-cap"
+
+	flagTransferTo    = "transfer-to"
+	flagTransferValue = "transfer-value"
+)
+
+const (
+	// workloadContract calls the method of a contract sampler.
+	workloadContract = "contract"
+	// workloadBlob sends EIP-4844 transactions carrying random blobs.
+	workloadBlob = "blob"
+	// workloadTransfer sends native tokens without any call data.
+	workloadTransfer = "transfer"
+)
+
+func addWorkloadFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagWorkload, workloadContract, "transactions sent, `contract` (calls --contract-method of --contract), `blob` (blob transactions of the sender to itself) or `transfer` (native tokens, no contract)")
+	cmd.Flags().String(flagMix, "", "JSON file of the weighted (contract, method, params) entries of the contract workload, replaces --contract and --contract-method")
+	cmd.Flags().Int(flagBlobCount, 1, "number of random blobs of every transaction of the blob workload")
+	cmd.Flags().Int64(flagBlobFeeCap, 0, "blob gas fee cap of the blob workload (0 = twice the blob base fee, fetch from chain)")
+	cmd.Flags().StringSlice(flagTransferTo, []string{tester.RandomRecipient}, "recipients of the transfer workload, one address, several addresses used in turn, or random for a new address every transfer")
+	cmd.Flags().String(flagTransferValue, "1", "value of every transfer of the transfer workload, in wei")
+}
+
+// loadWorkloadFlags loads the workload into txConf.
+func loadWorkloadFlags(cmd *cobra.Command, txConf *TransactionConfig) error {
+	workload, err := cmd.Flags().GetString(flagWorkload)
+	if err != nil {
+		return err
+	}
+
+	if txConf.mixPath, err = cmd.Flags().GetString(flagMix); err != nil {
+		return err
+	}
+	if txConf.mixPath != "" && workload != workloadContract {
+		return errors.Errorf("`--%s` requires the `%s` workload", flagMix, workloadContract)
+	}
+
+	switch workload {
+	case workloadContract:
+		if txConf.mixPath == "" && (txConf.contractMethod == "" || txConf.contractAddr == (common.Address{})) {
+			return errors.Errorf("the `%s` workload requires `--%s` and `--%s`, or `--%s`", workloadContract, flagContract, flagContractMethod, flagMix)
+		}
+	case workloadBlob:
+		if txConf.txType != tester.TxDynamicFee {
+			return errors.Errorf("the `%s` workload requires the `%s` transaction type", workloadBlob, tester.TxDynamicFee)
+		}
+		if txConf.blobs, err = cmd.Flags().GetInt(flagBlobCount); err != nil {
+			return err
+		}
+		blobFeeCap, err := cmd.Flags().GetInt64(flagBlobFeeCap)
+		if err != nil {
+			return err
+		}
+		// the fees of a blob transaction are unsigned
+		if blobFeeCap < 0 {
+			return errors.Errorf("`--%s` must not be negative", flagBlobFeeCap)
+		}
+		if txConf.gasFeeCap != nil && txConf.gasFeeCap.Sign() < 0 {
+			return errors.Errorf("`--%s` must not be negative", flagGasFeeCap)
+		}
+		if txConf.gasTipCap != nil && txConf.gasTipCap.Sign() < 0 {
+			return errors.Errorf("`--%s` must not be negative", flagGasTipCap)
+		}
+		txConf.blobFeeCap = feeFlag(blobFeeCap)
+	case workloadTransfer:
+		if txConf.recipient, txConf.value, err = loadTransferFlags(cmd); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("invalid workload: %s", workload)
+	}
+	txConf.workload = workload
+	return nil
+}
+
+// newCreateTx returns the transaction builder of the workload of txConf.
+func newCreateTx(conf *GlobalConfig, txConf *TransactionConfig) (tester.CreateTx, error) {
+	switch txConf.workload {
+	case workloadBlob:
+		return tester.NewBlobTx(conf.client, txConf.blobs, txConf.blobFeeCap, txConf.gasPricer)
+	case workloadTransfer:
+		return tester.NewTransferTx(conf.client, txConf.recipient), nil
+	default:
+		conf.contract.SetContractAddr(txConf.contractAddr)
+		return conf.contract.GenTxBuilder(conf.client, txConf.contractMethod, txConf.contractMethodParams)
+	}
+}
+
+// loadTransferFlags loads the recipients and the value of the transfer workload.
+func loadTransferFlags(cmd *cobra.Command) (tester.Recipient, *big.Int, error) {
+	valueStr, err := cmd.Flags().GetString(flagTransferValue)
+	if err != nil {
+		return nil, nil, err
+	}
+	to, err := cmd.Flags().GetStringSlice(flagTransferTo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(to) == 0 {
+		return nil, nil, errors.Errorf("the `%s` workload requires `--%s`", workloadTransfer, flagTransferTo)
+	}
+	return tester.ParseRecipient(to, valueStr)
+}
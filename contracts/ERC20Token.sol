@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: GPL-3.0
+
+pragma solidity >=0.7.0 <0.9.0;
+
+import "@openzeppelin/contracts/token/ERC20/ERC20.sol";
+
+contract ERC20Token is ERC20 {
+    constructor(
+        string memory name,
+        string memory symbol,
+        uint256 supply
+    ) ERC20(name, symbol) {
+        _mint(msg.sender, supply);
+    }
+}
@@ -0,0 +1,106 @@
+package tester
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	// RoundRobin represents sending to every endpoint in turn.
+	RoundRobin EndpointStrategy = "round-robin"
+	// Weighted represents sending to every endpoint in proportion to its weight.
+	Weighted EndpointStrategy = "weighted"
+	// Sticky represents sending all the transactions of a sender to the same endpoint.
+	Sticky EndpointStrategy = "sticky"
+)
+
+// EndpointStrategy represents the way transactions are spread over the endpoints.
+type EndpointStrategy string
+
+// ParseEndpointStrategy parses the input string and returns the corresponding
+// EndpointStrategy constant if it matches one of the defined strategies.
+// Otherwise, it returns an error.
+//
+// Parameters:
+// - strategy: The input string to be parsed.
+//
+// Return types:
+// - EndpointStrategy: The corresponding EndpointStrategy constant.
+// - error: An error if the input string does not match any defined strategies.
+func ParseEndpointStrategy(strategy string) (EndpointStrategy, error) {
+	switch strategy {
+	case string(RoundRobin):
+		return RoundRobin, nil
+	case string(Weighted):
+		return Weighted, nil
+	case string(Sticky):
+		return Sticky, nil
+	default:
+		return "", fmt.Errorf("invalid endpoint strategy: %s", strategy)
+	}
+}
+
+// Endpoint is a RPC node the transactions are sent to.
+type Endpoint struct {
+	URL    string
+	Weight int
+	Client *ethclient.Client
+}
+
+// EndpointSelector picks the endpoint every transaction is sent to.
+type EndpointSelector struct {
+	endpoints []*Endpoint
+	strategy  EndpointStrategy
+	schedule  []int
+	next      atomic.Uint64
+}
+
+// NewEndpointSelector creates a new EndpointSelector.
+//
+// Parameters:
+// - endpoints: the endpoints to spread the transactions over, at least one.
+// - strategy: the way the transactions are spread.
+//
+// Returns:
+// - a pointer to the EndpointSelector.
+func NewEndpointSelector(endpoints []*Endpoint, strategy EndpointStrategy) *EndpointSelector {
+	s := &EndpointSelector{
+		endpoints: endpoints,
+		strategy:  strategy,
+	}
+
+	// smooth weighted round-robin, so the endpoints are interleaved instead of
+	// being sent bursts of their weight
+	weights := make([]int, len(endpoints))
+	for i, ep := range endpoints {
+		weights[i] = 1
+		if strategy == Weighted && ep.Weight > 0 {
+			weights[i] = ep.Weight
+		}
+	}
+	s.schedule = smoothSchedule(weights)
+	return s
+}
+
+// Endpoints returns all the endpoints of the EndpointSelector.
+func (s *EndpointSelector) Endpoints() []*Endpoint {
+	return s.endpoints
+}
+
+// Pick returns the endpoint the next transaction of sender is sent to.
+func (s *EndpointSelector) Pick(sender common.Address) *Endpoint {
+	if len(s.endpoints) == 1 {
+		return s.endpoints[0]
+	}
+	if s.strategy == Sticky {
+		h := fnv.New32a()
+		h.Write(sender.Bytes())
+		return s.endpoints[h.Sum32()%uint32(len(s.endpoints))]
+	}
+	n := s.next.Add(1) - 1
+	return s.endpoints[s.schedule[n%uint64(len(s.schedule))]]
+}
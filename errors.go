@@ -0,0 +1,95 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+const (
+	// ErrClassNonceTooLow represents a transaction whose nonce was already used.
+	ErrClassNonceTooLow ErrorClass = "nonce too low"
+	// ErrClassNonceGap represents a transaction whose nonce is ahead of the account nonce.
+	ErrClassNonceGap ErrorClass = "nonce gap"
+	// ErrClassReplacementUnderpriced represents a transaction replacing another one without paying enough more.
+	ErrClassReplacementUnderpriced ErrorClass = "replacement underpriced"
+	// ErrClassFeeCapTooLow represents a transaction whose fee cap is below the block base fee.
+	ErrClassFeeCapTooLow ErrorClass = "fee cap below base fee"
+	// ErrClassInsufficientFunds represents a sender that can not pay for the transaction.
+	ErrClassInsufficientFunds ErrorClass = "insufficient funds"
+	// ErrClassTxPoolFull represents a node whose transaction pool is full.
+	ErrClassTxPoolFull ErrorClass = "txpool full"
+	// ErrClassTimeout represents a request that did not complete in time.
+	ErrClassTimeout ErrorClass = "timeout"
+	// ErrClassConnection represents a request that could not reach the node.
+	ErrClassConnection ErrorClass = "connection refused"
+	// ErrClassOther represents any other error.
+	ErrClassOther ErrorClass = "other"
+)
+
+// ErrorClasses lists all the error classes in the order they are reported.
+var ErrorClasses = []ErrorClass{
+	ErrClassNonceTooLow,
+	ErrClassNonceGap,
+	ErrClassReplacementUnderpriced,
+	ErrClassFeeCapTooLow,
+	ErrClassInsufficientFunds,
+	ErrClassTxPoolFull,
+	ErrClassTimeout,
+	ErrClassConnection,
+	ErrClassOther,
+}
+
+// errorPatterns maps lower-case fragments of the messages returned by the
+// common EVM node implementations to their error class.
+var errorPatterns = []struct {
+	class     ErrorClass
+	fragments []string
+}{
+	{ErrClassNonceTooLow, []string{"nonce too low", "invalid nonce", "nonce has already been used"}},
+	{ErrClassNonceGap, []string{"nonce too high", "nonce gap", "future transaction"}},
+	{ErrClassReplacementUnderpriced, []string{"replacement transaction underpriced", "replacement underpriced"}},
+	{ErrClassFeeCapTooLow, []string{"less than block base fee", "fee cap less than", "max fee per gas less than"}},
+	{ErrClassInsufficientFunds, []string{"insufficient funds", "insufficient balance"}},
+	{ErrClassTxPoolFull, []string{"txpool is full", "tx pool is full", "transaction pool is full", "mempool is full"}},
+	{ErrClassTimeout, []string{"timeout", "deadline exceeded"}},
+	{ErrClassConnection, []string{"connection refused", "connection reset", "broken pipe", "no such host"}},
+}
+
+// ErrorClass represents the class of a send error.
+type ErrorClass string
+
+// ClassifyError returns the class of an error returned by the node or the
+// RPC client while sending a transaction.
+//
+// Parameters:
+// - err: the error to classify, must not be nil.
+//
+// Returns:
+// - ErrorClass: the class of the error.
+func ClassifyError(err error) ErrorClass {
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range errorPatterns {
+		for _, fragment := range pattern.fragments {
+			if strings.Contains(msg, fragment) {
+				return pattern.class
+			}
+		}
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrClassTimeout
+	case errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET):
+		return ErrClassConnection
+	default:
+		return ErrClassOther
+	}
+}
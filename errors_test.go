@@ -0,0 +1,31 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorClass
+	}{
+		{errors.New("nonce too low: next nonce 5, tx nonce 3"), ErrClassNonceTooLow},
+		{errors.New("nonce too high"), ErrClassNonceGap},
+		{errors.New("replacement transaction underpriced"), ErrClassReplacementUnderpriced},
+		{errors.New("max fee per gas less than block base fee: address 0x0, maxFeePerGas: 1, baseFee: 7"), ErrClassFeeCapTooLow},
+		{errors.New("insufficient funds for gas * price + value: balance 0, tx cost 21000"), ErrClassInsufficientFunds},
+		{errors.New("txpool is full"), ErrClassTxPoolFull},
+		{fmt.Errorf("post failed: %w", context.DeadlineExceeded), ErrClassTimeout},
+		{fmt.Errorf("dial tcp 127.0.0.1:8545: %w", syscall.ECONNREFUSED), ErrClassConnection},
+		{errors.New("already known"), ErrClassOther},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, ClassifyError(tt.err), tt.err.Error())
+	}
+}
@@ -0,0 +1,278 @@
+package tester
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+)
+
+const (
+	// GasFixed represents using the configured fee caps, nil caps are left to bind.TransactOpts.
+	GasFixed GasStrategy = "fixed"
+	// GasOracle represents using the suggested tip cap and the base fee of the latest header.
+	GasOracle GasStrategy = "oracle"
+	// GasFeeHistory represents using a percentile of the tips paid in the latest blocks.
+	GasFeeHistory GasStrategy = "fee-history"
+	// GasRandom represents drawing the tip cap of every transaction within a range.
+	GasRandom GasStrategy = "random"
+)
+
+// GasStrategy represents the way the fee caps of the transactions are priced.
+type GasStrategy string
+
+// ParseGasStrategy parses the input string and returns the corresponding GasStrategy.
+//
+// Parameters:
+// - strategy: The input string to be parsed.
+//
+// Return types:
+// - GasStrategy: The corresponding GasStrategy constant.
+// - error: An error if the input string does not match any of the defined strategies.
+func ParseGasStrategy(strategy string) (GasStrategy, error) {
+	switch strategy {
+	case string(GasFixed):
+		return GasFixed, nil
+	case string(GasOracle):
+		return GasOracle, nil
+	case string(GasFeeHistory):
+		return GasFeeHistory, nil
+	case string(GasRandom):
+		return GasRandom, nil
+	default:
+		return "", fmt.Errorf("invalid gas strategy: %s", strategy)
+	}
+}
+
+// GasConfig is the configuration of a GasPricer.
+type GasConfig struct {
+	Strategy   GasStrategy
+	GasFeeCap  *big.Int      // fixed fee cap, or the highest fee cap of the other strategies, nil when unset
+	GasTipCap  *big.Int      // fixed tip cap, nil when unset
+	MinTipCap  *big.Int      // lowest tip cap of the random strategy
+	MaxTipCap  *big.Int      // highest tip cap of the random strategy
+	Percentile float64       // percentile of the tips of the fee-history strategy
+	Blocks     uint64        // number of blocks of the fee-history strategy
+	Refresh    time.Duration // time between two refreshes of the prices, 0 prices once
+}
+
+// GasPricer gives the fee caps of the transactions and refreshes them while a
+// run goes on.
+//
+// The fee cap is the tip cap plus twice the base fee, like bind.TransactOpts
+// computes it, so that the transactions stay valid over a few full blocks.
+type GasPricer struct {
+	conf GasConfig
+	eth  *ethclient.Client
+	stop chan struct{}
+	once sync.Once
+
+	mu          sync.RWMutex
+	baseFee     *big.Int
+	tipCap      *big.Int
+	blobBaseFee *big.Int // nil on the chains without blobs
+
+	// the tip caps of the random strategy, created by the first draw
+	randMu sync.Mutex
+	rand   *rand.Rand
+}
+
+// NewGasPricer creates a new GasPricer and fetches the first prices.
+//
+// Parameters:
+// - eth: the client the prices are fetched from.
+// - conf: the configuration of the pricer.
+//
+// Returns:
+// - *GasPricer: the pricer.
+// - error: An error if the configuration is invalid or the prices can not be fetched.
+func NewGasPricer(eth *ethclient.Client, conf GasConfig) (*GasPricer, error) {
+	switch conf.Strategy {
+	case GasFeeHistory:
+		if conf.Percentile < 0 || conf.Percentile > 100 {
+			return nil, fmt.Errorf("invalid fee history percentile: %v", conf.Percentile)
+		}
+		if conf.Blocks == 0 {
+			return nil, errors.New("fee history requires at least one block")
+		}
+	case GasRandom:
+		if conf.MinTipCap == nil || conf.MaxTipCap == nil || conf.MinTipCap.Cmp(conf.MaxTipCap) > 0 {
+			return nil, errors.New("random gas strategy requires a tip cap range with min <= max")
+		}
+	}
+
+	p := &GasPricer{
+		conf: conf,
+		eth:  eth,
+		stop: make(chan struct{}),
+	}
+	if conf.Strategy == GasFixed {
+		return p, nil
+	}
+	if err := p.refresh(context.Background()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Start refreshes the prices until the GasPricer is stopped.
+func (p *GasPricer) Start() {
+	if p == nil || p.conf.Strategy == GasFixed || p.conf.Refresh <= 0 {
+		return
+	}
+	tick := time.NewTicker(p.conf.Refresh)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			if err := p.refresh(context.Background()); err != nil {
+				slog.Error("failed to refresh gas prices", "strategy", p.conf.Strategy, "err", err)
+			}
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// Stop stops refreshing the prices.
+func (p *GasPricer) Stop() {
+	if p == nil {
+		return
+	}
+	p.once.Do(func() { close(p.stop) })
+}
+
+// Fees returns the fee cap and the tip cap of the next transaction, nil caps
+// are left to bind.TransactOpts.
+func (p *GasPricer) Fees() (*big.Int, *big.Int) {
+	if p.conf.Strategy == GasFixed {
+		return p.conf.GasFeeCap, p.conf.GasTipCap
+	}
+
+	p.mu.RLock()
+	baseFee, tipCap := p.baseFee, p.tipCap
+	p.mu.RUnlock()
+
+	if p.conf.Strategy == GasRandom {
+		tipCap = p.randomTipCap()
+	}
+	return p.fees(baseFee, tipCap)
+}
+
+// randomTipCap draws a tip cap within the range of the random strategy.
+func (p *GasPricer) randomTipCap() *big.Int {
+	span := new(big.Int).Sub(p.conf.MaxTipCap, p.conf.MinTipCap)
+	span.Add(span, big.NewInt(1))
+
+	p.randMu.Lock()
+	if p.rand == nil {
+		p.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	tipCap := new(big.Int).Rand(p.rand, span)
+	p.randMu.Unlock()
+
+	return tipCap.Add(tipCap, p.conf.MinTipCap)
+}
+
+// BlobFeeCap returns twice the blob base fee of the latest refresh, like
+// NewBlobTx computes it, or nil when it is unknown.
+func (p *GasPricer) BlobFeeCap() *big.Int {
+	if p == nil {
+		return nil
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.blobBaseFee == nil {
+		return nil
+	}
+	return new(big.Int).Mul(p.blobBaseFee, big.NewInt(2))
+}
+
+// Ceiling returns the highest fee cap and tip cap the next transaction may get.
+func (p *GasPricer) Ceiling() (*big.Int, *big.Int) {
+	if p.conf.Strategy != GasRandom {
+		return p.Fees()
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.fees(p.baseFee, p.conf.MaxTipCap)
+}
+
+// fees returns the fee cap of tipCap, up to the configured fee cap, and the
+// tip cap, which can not be above the fee cap.
+func (p *GasPricer) fees(baseFee, tipCap *big.Int) (*big.Int, *big.Int) {
+	feeCap := new(big.Int).Add(tipCap, new(big.Int).Mul(baseFee, big.NewInt(2)))
+	tipCap = new(big.Int).Set(tipCap)
+	if p.conf.GasFeeCap != nil && feeCap.Cmp(p.conf.GasFeeCap) > 0 {
+		feeCap.Set(p.conf.GasFeeCap)
+		if tipCap.Cmp(feeCap) > 0 {
+			tipCap.Set(feeCap)
+		}
+	}
+	return feeCap, tipCap
+}
+
+// refresh fetches the base fee and the tip cap of the strategy.
+func (p *GasPricer) refresh(ctx context.Context) error {
+	var (
+		baseFee = new(big.Int)
+		tipCap  *big.Int
+	)
+	head, err := p.eth.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch the latest header")
+	}
+	var blobBaseFee *big.Int
+	if head.ExcessBlobGas != nil {
+		blobBaseFee = eip4844.CalcBlobFee(*head.ExcessBlobGas)
+	}
+
+	switch p.conf.Strategy {
+	case GasFeeHistory:
+		history, err := p.eth.FeeHistory(ctx, p.conf.Blocks, nil, []float64{p.conf.Percentile})
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch the fee history")
+		}
+		// the last base fee is the one of the next block
+		if n := len(history.BaseFee); n > 0 {
+			baseFee = history.BaseFee[n-1]
+		}
+		tipCap = new(big.Int)
+		for _, reward := range history.Reward {
+			tipCap.Add(tipCap, reward[0])
+		}
+		if len(history.Reward) > 0 {
+			tipCap.Div(tipCap, big.NewInt(int64(len(history.Reward))))
+		}
+	default:
+		if head.BaseFee != nil {
+			baseFee = head.BaseFee
+		}
+		if p.conf.Strategy == GasOracle {
+			if tipCap, err = p.eth.SuggestGasTipCap(ctx); err != nil {
+				return errors.Wrap(err, "failed to suggest the gas tip cap")
+			}
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.baseFee, p.blobBaseFee = baseFee, blobBaseFee
+	if tipCap != nil {
+		p.tipCap = tipCap
+	}
+	if p.conf.Strategy == GasRandom {
+		slog.Info("refresh gas prices", "strategy", p.conf.Strategy, "baseFee", baseFee, "minTipCap", p.conf.MinTipCap, "maxTipCap", p.conf.MaxTipCap)
+		return nil
+	}
+	slog.Info("refresh gas prices", "strategy", p.conf.Strategy, "baseFee", baseFee, "tipCap", p.tipCap)
+	return nil
+}
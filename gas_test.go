@@ -0,0 +1,51 @@
+package tester
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasPricerFees(t *testing.T) {
+	fixed := &GasPricer{conf: GasConfig{Strategy: GasFixed}}
+	feeCap, tipCap := fixed.Fees()
+	require.Nil(t, feeCap)
+	require.Nil(t, tipCap)
+
+	random := &GasPricer{
+		conf: GasConfig{
+			Strategy:  GasRandom,
+			GasFeeCap: big.NewInt(250),
+			MinTipCap: big.NewInt(10),
+			MaxTipCap: big.NewInt(100),
+		},
+		baseFee: big.NewInt(70),
+	}
+	for i := 0; i < 100; i++ {
+		feeCap, tipCap := random.Fees()
+		require.True(t, tipCap.Cmp(big.NewInt(10)) >= 0 && tipCap.Cmp(big.NewInt(100)) <= 0, tipCap)
+		// tip cap plus twice the base fee, up to the configured fee cap
+		require.Equal(t, min(tipCap.Int64()+140, 250), feeCap.Int64())
+	}
+
+	feeCap, tipCap = random.Ceiling()
+	require.Equal(t, int64(240), feeCap.Int64())
+	require.Equal(t, int64(100), tipCap.Int64())
+
+	// a configured fee cap below the tip cap caps the tip cap as well
+	capped := &GasPricer{
+		conf:    GasConfig{Strategy: GasOracle, GasFeeCap: big.NewInt(50)},
+		baseFee: big.NewInt(70),
+		tipCap:  big.NewInt(80),
+	}
+	feeCap, tipCap = capped.Fees()
+	require.Equal(t, int64(50), feeCap.Int64())
+	require.Equal(t, int64(50), tipCap.Int64())
+	require.Equal(t, int64(80), capped.tipCap.Int64())
+
+	_, err := ParseGasStrategy("fee-history")
+	require.NoError(t, err)
+	_, err = ParseGasStrategy("auction")
+	require.Error(t, err)
+}
@@ -7,8 +7,10 @@ import (
 	"math/big"
 	"net/http"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -22,8 +24,10 @@ var ErrExit = errors.New("exit")
 // Payload is a struct that contains the raw transaction and the chain ID.
 type Payload struct {
 	Tx      *types.Transaction `csv:"-"`
+	From    common.Address     `csv:"-"`
 	RawTx   string             `csv:"raw_tx"`
 	ChainID string             `csv:"chain_id"`
+	Entry   string             `csv:"-"` // the mix entry of the transaction, empty without a mix
 }
 
 // Option is a function type that can be used to configure the TxGenerator.
@@ -54,6 +58,36 @@ func SetGasTipCap(gasTipCap *big.Int) Option {
 	}
 }
 
+// SetGasPricer sets the GasPricer the TxGenerator takes the fee caps of every
+// transaction from, it overrides SetGasFeeCap and SetGasTipCap.
+func SetGasPricer(pricer *GasPricer) Option {
+	return func(tg *TxGenerator) *TxGenerator {
+		tg.pricer = pricer
+		return tg
+	}
+}
+
+// SetTxType sets the type of the transactions generated by the TxGenerator, and
+// the access lists they carry when lists is not nil. The gas price of the legacy
+// and access list transactions without a fee cap is suggested by backend.
+func SetTxType(txType TxType, lists *AccessLists, backend bind.ContractTransactor) Option {
+	return func(tg *TxGenerator) *TxGenerator {
+		tg.txType = txType
+		tg.accessLists = lists
+		tg.backend = backend
+		return tg
+	}
+}
+
+// SetMix sets the Mix the TxGenerator takes the builder of every transaction
+// from, it overrides the builder given to NewTxGenerator.
+func SetMix(mix *Mix) Option {
+	return func(tg *TxGenerator) *TxGenerator {
+		tg.mix = mix
+		return tg
+	}
+}
+
 // SetGasLimit sets the gas limit option for the TxGenerator.
 func SetGasLimit(gasLimit uint64) Option {
 	return func(tg *TxGenerator) *TxGenerator {
@@ -113,6 +147,17 @@ func SetPrivKey(privKey *ecdsa.PrivateKey) Option {
 	}
 }
 
+// SetSenderPool sets the accounts the TxGenerator spreads the transactions over.
+//
+// senders: the sender pool, it takes precedence over the private key.
+// Returns: the TxGenerator with the updated sender pool.
+func SetSenderPool(senders *SenderPool) Option {
+	return func(tg *TxGenerator) *TxGenerator {
+		tg.senders = senders
+		return tg
+	}
+}
+
 // CreateTx is a function type that can create or send transactions.
 type CreateTx func(opts *bind.TransactOpts) (*types.Transaction, error)
 
@@ -126,8 +171,16 @@ type TxGenerator struct {
 	gasLimit   uint64   // Gas limit to set for the transaction execution (0 = estimate)
 	batchSize  uint64
 	privKey    *ecdsa.PrivateKey
+	senders    *SenderPool
 	nonce      int64
+	nonceShift atomic.Int64 // moves the nonce of the private key before the next batch
 	concurrent bool
+	pricer     *GasPricer
+	mix        *Mix
+
+	txType      TxType
+	accessLists *AccessLists
+	backend     bind.ContractTransactor
 }
 
 // NewTxGenerator initializes a new instance of the TxGenerator struct.
@@ -171,7 +224,11 @@ func (tg *TxGenerator) Run() ([]*Payload, bool, error) {
 	case tg.concurrent:
 		data, err = tg.RandomBatchGenTxs()
 		break
+	case tg.senders != nil:
+		data, err = tg.SenderPoolBatchGenTxs()
+		break
 	case tg.privKey != nil:
+		tg.nonce += tg.nonceShift.Swap(0)
 		data, err = tg.BatchGenTxs(tg.privKey, big.NewInt(tg.nonce))
 		break
 	default:
@@ -187,6 +244,20 @@ func (tg *TxGenerator) Run() ([]*Payload, bool, error) {
 	return data, false, err
 }
 
+// shiftNonce moves the nonce of the next transaction generated for the sender
+// addr by delta, when the sender is one of the generator.
+func (tg *TxGenerator) shiftNonce(addr common.Address, delta int64) {
+	if tg.senders != nil {
+		if sender := tg.senders.Get(addr); sender != nil {
+			sender.shift(delta)
+		}
+		return
+	}
+	if tg.privKey != nil && crypto.PubkeyToAddress(tg.privKey.PublicKey) == addr {
+		tg.nonceShift.Add(delta)
+	}
+}
+
 // GenTx generates a transaction using the provided sender's private key, sender nonce, and player address.
 //
 // Parameters:
@@ -197,7 +268,13 @@ func (tg *TxGenerator) Run() ([]*Payload, bool, error) {
 // Returns:
 // - The hexadecimal representation of the generated transaction.
 func (tg *TxGenerator) GenTx(sender *ecdsa.PrivateKey, senderNonce *big.Int) (*Payload, error) {
-	rawTransaction, err := tg.genTx(sender, senderNonce)
+	createTx, entry := tg.createTx, ""
+	if tg.mix != nil {
+		next := tg.mix.Next()
+		createTx, entry = next.CreateTx, next.Name
+	}
+
+	rawTransaction, err := tg.genTx(sender, senderNonce, createTx)
 	if err != nil {
 		return nil, err
 	}
@@ -208,12 +285,14 @@ func (tg *TxGenerator) GenTx(sender *ecdsa.PrivateKey, senderNonce *big.Int) (*P
 	}
 	return &Payload{
 		Tx:      rawTransaction,
+		From:    crypto.PubkeyToAddress(sender.PublicKey),
 		RawTx:   hexutil.Bytes(txbz).String(),
 		ChainID: tg.chainID.String(),
+		Entry:   entry,
 	}, nil
 }
 
-func (tg *TxGenerator) genTx(sender *ecdsa.PrivateKey, senderNonce *big.Int) (*types.Transaction, error) {
+func (tg *TxGenerator) genTx(sender *ecdsa.PrivateKey, senderNonce *big.Int, createTx CreateTx) (*types.Transaction, error) {
 	// Create an authorized transactor and call the store function
 	auth, err := bind.NewKeyedTransactorWithChainID(sender, tg.chainID)
 	if err != nil {
@@ -224,12 +303,18 @@ func (tg *TxGenerator) genTx(sender *ecdsa.PrivateKey, senderNonce *big.Int) (*t
 	auth.GasLimit = tg.gasLimit
 	auth.GasTipCap = tg.gasTipCap
 	auth.GasFeeCap = tg.gasFeeCap
+	if tg.pricer != nil {
+		auth.GasFeeCap, auth.GasTipCap = tg.pricer.Fees()
+	}
 
 	header := make(http.Header)
 	header.Add("X-Chain", tg.chainID.String())
 	auth.Context = rpc.NewContextWithHeaders(context.Background(), header)
+	if err := ApplyTxType(auth, tg.txType, tg.accessLists, tg.backend); err != nil {
+		return nil, err
+	}
 
-	rawTransaction, err := tg.createTx(auth)
+	rawTransaction, err := createTx(auth)
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +349,35 @@ func (tg *TxGenerator) BatchGenTxs(sender *ecdsa.PrivateKey, senderNonce *big.In
 	return txs, nil
 }
 
+// SenderPoolBatchGenTxs generates a batch of transactions spread over the accounts of the sender pool.
+//
+// Every account signs its transactions with its own increasing nonce, the
+// nonces taken by a batch that fails are given back to the accounts.
+//
+// Return:
+// - []*Payload: The generated transactions.
+func (tg *TxGenerator) SenderPoolBatchGenTxs() ([]*Payload, error) {
+	txs := make([]*Payload, 0, tg.batchSize)
+	for i := uint64(0); i < tg.batchSize; i++ {
+		sender := tg.senders.Next()
+		tx, err := tg.GenTx(sender.Key, new(big.Int).SetUint64(sender.NextNonce()))
+		if err != nil {
+			// the nonces of the transactions that are not sent are given back,
+			// the next batch would otherwise leave a gap
+			sender.shift(-1)
+			if errors.Is(err, ErrExit) {
+				return txs, err
+			}
+			for _, tx := range txs {
+				tg.senders.Get(tx.From).shift(-1)
+			}
+			return nil, errors.Wrap(err, "failed to generate transaction")
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
 // RandomGenTx generates a random transaction for the given player address.
 //
 // player: the address of the player.
@@ -0,0 +1,112 @@
+package tester
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultHDPath is the BIP-44 path of the Ethereum accounts, the account index is appended to it.
+const DefaultHDPath = "m/44'/60'/0'/0"
+
+// DeriveKeys derives a range of private keys from a BIP-39 mnemonic.
+//
+// Parameters:
+// - mnemonic: the BIP-39 mnemonic.
+// - passphrase: the optional BIP-39 passphrase.
+// - basePath: the derivation path the account index is appended to, eg: DefaultHDPath.
+// - from: the index of the first account.
+// - count: the number of accounts.
+//
+// Returns:
+// - []*ecdsa.PrivateKey: the private keys of the accounts from/from+count-1.
+// - error: An error if the mnemonic or the path is invalid.
+func DeriveKeys(mnemonic, passphrase, basePath string, from, count uint32) ([]*ecdsa.PrivateKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid mnemonic")
+	}
+
+	path, err := accounts.ParseDerivationPath(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*ecdsa.PrivateKey, 0, count)
+	for i := uint32(0); i < count; i++ {
+		index := from + i
+		if index >= 0x80000000 {
+			return nil, fmt.Errorf("invalid account index: %d", index)
+		}
+		key, err := DeriveKey(seed, append(path[:len(path):len(path)], index))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// DeriveKey derives the private key at path from a BIP-32 seed.
+//
+// Parameters:
+// - seed: the BIP-32 seed, eg: the seed of a BIP-39 mnemonic.
+// - path: the derivation path of the key.
+//
+// Returns:
+// - *ecdsa.PrivateKey: the private key at path.
+// - error: An error if the path leads to an invalid key.
+func DeriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, chainCode := sum[:32], sum[32:]
+	for _, index := range path {
+		var err error
+		if key, chainCode, err = deriveChild(key, chainCode, index); err != nil {
+			return nil, errors.Wrapf(err, "failed to derive %s", path)
+		}
+	}
+	return crypto.ToECDSA(key)
+}
+
+// deriveChild derives the private child key at index, as specified by BIP-32.
+func deriveChild(key, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	var data []byte
+	if index >= 0x80000000 {
+		// hardened child, derived from the private key
+		data = append([]byte{0}, key...)
+	} else {
+		parent, err := crypto.ToECDSA(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = crypto.CompressPubkey(&parent.PublicKey)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := crypto.S256().Params().N
+	tweak := new(big.Int).SetBytes(sum[:32])
+	if tweak.Cmp(n) >= 0 {
+		return nil, nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+	child := tweak.Add(tweak, new(big.Int).SetBytes(key))
+	child.Mod(child, n)
+	if child.Sign() == 0 {
+		return nil, nil, fmt.Errorf("invalid child key at index %d", index)
+	}
+	return child.FillBytes(make([]byte, 32)), sum[32:], nil
+}
@@ -0,0 +1,31 @@
+package tester
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveKeys(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+	keys, err := DeriveKeys(mnemonic, "", DefaultHDPath, 0, 3)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+
+	want := []string{
+		"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+	}
+	for i, key := range keys {
+		require.Equal(t, want[i], crypto.PubkeyToAddress(key.PublicKey).Hex())
+	}
+
+	keys, err = DeriveKeys(mnemonic, "", DefaultHDPath, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, want[2], crypto.PubkeyToAddress(keys[0].PublicKey).Hex())
+
+	_, err = DeriveKeys("test test junk", "", DefaultHDPath, 0, 1)
+	require.Error(t, err)
+}
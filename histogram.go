@@ -0,0 +1,143 @@
+package tester
+
+import (
+	"math"
+	"math/bits"
+)
+
+// histSubBucketBits is the number of bits used to split every power of two
+// into sub-buckets, 2^7 sub-buckets keep the relative error below 1%.
+const histSubBucketBits = 7
+
+const (
+	histSubBucketCount = 1 << histSubBucketBits
+	histSubBucketHalf  = histSubBucketCount / 2
+)
+
+// Histogram is a HDR-style histogram of non-negative int64 values.
+//
+// Values are grouped into logarithmic buckets that are linearly split into
+// sub-buckets, so it records an unbounded number of values in a fixed amount
+// of memory with a bounded relative error. It is not safe for concurrent use.
+type Histogram struct {
+	counts []int64
+	count  int64
+	sum    float64
+	min    int64
+	max    int64
+}
+
+// NewHistogram creates a new empty Histogram.
+func NewHistogram() *Histogram {
+	return &Histogram{}
+}
+
+// Record adds a value to the Histogram, negative values are recorded as zero.
+func (h *Histogram) Record(v int64) {
+	v = max(v, 0)
+	idx := histIndex(v)
+	if idx >= len(h.counts) {
+		counts := make([]int64, idx+1)
+		copy(counts, h.counts)
+		h.counts = counts
+	}
+	h.counts[idx]++
+
+	if h.count == 0 || v < h.min {
+		h.min = v
+	}
+	if v > h.max {
+		h.max = v
+	}
+	h.count++
+	h.sum += float64(v)
+}
+
+// Merge adds all values recorded by other to the Histogram.
+func (h *Histogram) Merge(other *Histogram) {
+	if other == nil || other.count == 0 {
+		return
+	}
+	if len(other.counts) > len(h.counts) {
+		counts := make([]int64, len(other.counts))
+		copy(counts, h.counts)
+		h.counts = counts
+	}
+	for idx, c := range other.counts {
+		h.counts[idx] += c
+	}
+	if h.count == 0 || other.min < h.min {
+		h.min = other.min
+	}
+	h.max = max(h.max, other.max)
+	h.count += other.count
+	h.sum += other.sum
+}
+
+// Count returns the number of recorded values.
+func (h *Histogram) Count() int64 {
+	return h.count
+}
+
+// Min returns the smallest recorded value.
+func (h *Histogram) Min() int64 {
+	return h.min
+}
+
+// Max returns the largest recorded value.
+func (h *Histogram) Max() int64 {
+	return h.max
+}
+
+// Mean returns the exact arithmetic mean of the recorded values.
+func (h *Histogram) Mean() float64 {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / float64(h.count)
+}
+
+// ValueAtPercentile returns the value below which the given percentage of
+// the recorded values fall.
+//
+// Parameters:
+// - percentile: the percentile to query, between 0 and 100.
+//
+// Returns:
+// - the value at the percentile, within the precision of the Histogram.
+func (h *Histogram) ValueAtPercentile(percentile float64) int64 {
+	if h.count == 0 {
+		return 0
+	}
+	percentile = min(max(percentile, 0), 100)
+	rank := int64(math.Ceil(percentile / 100 * float64(h.count)))
+	rank = max(rank, 1)
+
+	var seen int64
+	for idx, c := range h.counts {
+		seen += c
+		if seen >= rank {
+			return min(max(histHighest(idx), h.min), h.max)
+		}
+	}
+	return h.max
+}
+
+// histIndex returns the index of the bucket v belongs to.
+func histIndex(v int64) int {
+	if v < histSubBucketCount {
+		return int(v)
+	}
+	shift := bits.Len64(uint64(v)) - histSubBucketBits
+	return shift*histSubBucketHalf + int(v>>shift)
+}
+
+// histHighest returns the highest value that falls into the bucket idx.
+func histHighest(idx int) int64 {
+	if idx < histSubBucketCount {
+		return int64(idx)
+	}
+	shift := idx/histSubBucketHalf - 1
+	sub := int64(idx - shift*histSubBucketHalf)
+	return (sub+1)<<shift - 1
+}
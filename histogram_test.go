@@ -0,0 +1,43 @@
+package tester
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram()
+	for v := int64(1); v <= 100000; v++ {
+		h.Record(v * 1000)
+	}
+
+	require.Equal(t, int64(100000), h.Count())
+	require.Equal(t, int64(1000), h.Min())
+	require.Equal(t, int64(100000000), h.Max())
+	require.InDelta(t, 50000500, h.Mean(), 0.001)
+
+	for _, p := range []float64{50, 90, 95, 99, 99.9} {
+		want := p * 1000 * 1000
+		require.InEpsilon(t, want, float64(h.ValueAtPercentile(p)), 0.01, "p%v", p)
+	}
+	require.Equal(t, h.Max(), h.ValueAtPercentile(100))
+
+	other := NewHistogram()
+	other.Record(1)
+	other.Record(200000000)
+	h.Merge(other)
+	require.Equal(t, int64(100002), h.Count())
+	require.Equal(t, int64(1), h.Min())
+	require.Equal(t, int64(200000000), h.Max())
+}
+
+func TestHistogramIndex(t *testing.T) {
+	for _, v := range []int64{0, 1, 127, 128, 129, 255, 256, 1 << 20, 1<<40 + 12345, 1<<62 + 1} {
+		idx := histIndex(v)
+		require.GreaterOrEqual(t, histHighest(idx), v)
+		if idx > 0 {
+			require.Less(t, histHighest(idx-1), v)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package tester
+
+import (
+	"sync"
+	"time"
+)
+
+// limiter paces callers to a target number of events per second.
+//
+// It is open-loop: the schedule only depends on the configured rate, never on
+// how long the work released by Wait takes to complete. The n-th event is
+// scheduled at start + n*interval, so the rounding of the interval and the late
+// wake-ups never add up.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	// start is the slot of the first event since the last rate change, zero
+	// until the first call to Wait
+	start time.Time
+	n     int64
+}
+
+// newLimiter creates a limiter releasing rate events per second.
+//
+// A rate less than or equal to zero disables pacing.
+func newLimiter(rate float64) *limiter {
+	l := &limiter{}
+	l.SetRate(rate)
+	return l
+}
+
+// SetRate changes the target rate, taking effect from the next call to Wait.
+func (l *limiter) SetRate(rate float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	interval := time.Duration(0)
+	if rate > 0 {
+		interval = time.Duration(float64(time.Second) / rate)
+	}
+	if interval == l.interval {
+		return
+	}
+	// the new schedule starts from the next slot of the old one
+	if l.interval == 0 {
+		l.start = time.Time{}
+	} else if !l.start.IsZero() {
+		l.start = l.start.Add(time.Duration(l.n) * l.interval)
+	}
+	l.n = 0
+	l.interval = interval
+}
+
+// Wait blocks until the next event is allowed to start.
+//
+// If the caller falls behind the schedule, the missed slots are released right
+// away until it catches up, so the target rate is still delivered over the run.
+func (l *limiter) Wait() {
+	l.mu.Lock()
+	if l.interval == 0 {
+		l.mu.Unlock()
+		return
+	}
+	if l.start.IsZero() {
+		l.start = time.Now()
+	}
+	at := l.start.Add(time.Duration(l.n) * l.interval)
+	l.n++
+	l.mu.Unlock()
+
+	time.Sleep(time.Until(at))
+}
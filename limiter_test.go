@@ -0,0 +1,41 @@
+package tester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(100)
+	require.Equal(t, 10*time.Millisecond, l.interval)
+
+	// the slots missed while the caller is late are released right away
+	l.Wait()
+	start := l.start
+	time.Sleep(50 * time.Millisecond)
+	begin := time.Now()
+	for i := 0; i < 4; i++ {
+		l.Wait()
+	}
+	require.Less(t, time.Since(begin), 10*time.Millisecond)
+	require.Equal(t, int64(5), l.n)
+
+	// a new rate starts from the next slot of the old schedule
+	l.SetRate(50)
+	require.Equal(t, start.Add(50*time.Millisecond), l.start)
+	require.Zero(t, l.n)
+	l.SetRate(50)
+	require.Equal(t, start.Add(50*time.Millisecond), l.start)
+
+	// no pacing, then a schedule starting at the next call to Wait
+	l.SetRate(0)
+	l.Wait()
+	require.Zero(t, l.n)
+	l.SetRate(100)
+	require.True(t, l.start.IsZero())
+	l.Wait()
+	require.False(t, l.start.IsZero())
+	require.Equal(t, int64(1), l.n)
+}
@@ -0,0 +1,154 @@
+package tester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "evm_tester"
+
+// Metrics exposes the state of a running Transactor to Prometheus.
+//
+// All methods are safe to call on a nil *Metrics, which disables them.
+type Metrics struct {
+	server   *http.Server
+	listener net.Listener
+	registry *prometheus.Registry
+	sent     prometheus.Counter
+	failed   *prometheus.CounterVec
+	latency  prometheus.Histogram
+	verified *prometheus.CounterVec
+}
+
+// NewMetrics creates a new Metrics serving the Prometheus endpoint on addr.
+//
+// The address is listened on right away, so that a busy address fails the run
+// before it starts.
+//
+// Parameters:
+// - addr: the listen address of the endpoint, eg: `:9090`.
+//
+// Returns:
+// - a pointer to the Metrics.
+// - error: An error if addr can not be listened on.
+func NewMetrics(addr string) (*Metrics, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on the metrics address %s: %w", addr, err)
+	}
+
+	registry := prometheus.NewRegistry()
+	m := &Metrics{
+		listener: listener,
+		registry: registry,
+		sent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "txs_sent_total",
+			Help:      "Number of transactions sent to the node.",
+		}),
+		failed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "txs_failed_total",
+			Help:      "Number of transactions the node failed to accept, by error class.",
+		}, []string{"class"}),
+		latency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "send_latency_seconds",
+			Help:      "Round-trip time of sending a transaction.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		}),
+		verified: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "txs_verified_total",
+			Help:      "Number of transactions checked by the verifier, by status.",
+		}, []string{"status"}),
+	}
+	registry.MustRegister(m.sent, m.failed, m.latency, m.verified)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+	m.server = &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return m, nil
+}
+
+// watchPools exposes the utilization of the pools.
+func (m *Metrics) watchPools(pools ...*Pool) {
+	if m == nil {
+		return
+	}
+	for _, pool := range pools {
+		pool := pool
+		gauge := func(name, help string, value func(Stat) int) prometheus.Collector {
+			return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Namespace:   metricsNamespace,
+				Name:        name,
+				Help:        help,
+				ConstLabels: prometheus.Labels{"pool": pool.service},
+			}, func() float64 {
+				return float64(value(pool.Stat()))
+			})
+		}
+		m.registry.MustRegister(
+			gauge("pool_running", "Number of running goroutines of the pool.", func(s Stat) int { return s.Running }),
+			gauge("pool_waiting", "Number of tasks waiting for a goroutine of the pool.", func(s Stat) int { return s.Waiting }),
+			gauge("pool_capacity", "Capacity of the pool.", func(s Stat) int { return s.Cap }),
+		)
+	}
+}
+
+// Serve serves the Prometheus endpoint until Close is called.
+func (m *Metrics) Serve() {
+	if m == nil {
+		return
+	}
+	slog.Info("serve prometheus metrics", "addr", m.listener.Addr())
+	if err := m.server.Serve(m.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("failed to serve prometheus metrics", "err", err)
+	}
+}
+
+// Close stops serving the Prometheus endpoint.
+func (m *Metrics) Close() {
+	if m == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = m.server.Shutdown(ctx)
+}
+
+func (m *Metrics) txSent(count int) {
+	if m == nil {
+		return
+	}
+	m.sent.Add(float64(count))
+}
+
+func (m *Metrics) txDone(err error, took int64) {
+	if m == nil {
+		return
+	}
+	m.latency.Observe(time.Duration(took).Seconds())
+	if err != nil {
+		m.failed.WithLabelValues(string(ClassifyError(err))).Inc()
+	}
+}
+
+func (m *Metrics) txVerified(status string) {
+	if m == nil {
+		return
+	}
+	m.verified.WithLabelValues(status).Inc()
+}
@@ -0,0 +1,104 @@
+package tester
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// MixEntry is a transaction builder of a Mix, sent in proportion to its weight.
+type MixEntry struct {
+	Name     string
+	Weight   int
+	CreateTx CreateTx
+}
+
+// Mix interleaves the transactions of several builders by weight, so that a
+// single run sends a mix of methods and contracts.
+type Mix struct {
+	entries  []*MixEntry
+	schedule []int
+	next     atomic.Uint64
+}
+
+// NewMix creates a new Mix.
+//
+// Parameters:
+// - entries: the builders of the mix, at least one, with distinct names and positive weights.
+//
+// Returns:
+// - *Mix: the mix, the entries are interleaved with the smooth weighted round-robin.
+// - error: An error if an entry is invalid.
+func NewMix(entries []*MixEntry) (*Mix, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("empty mix")
+	}
+
+	names := make(map[string]bool, len(entries))
+	weights := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Weight <= 0 {
+			return nil, fmt.Errorf("invalid weight of mix entry %s: %d", entry.Name, entry.Weight)
+		}
+		if names[entry.Name] {
+			return nil, fmt.Errorf("duplicate mix entry: %s", entry.Name)
+		}
+		names[entry.Name] = true
+		weights = append(weights, entry.Weight)
+	}
+	return &Mix{
+		entries:  entries,
+		schedule: smoothSchedule(weights),
+	}, nil
+}
+
+// Entries returns all the entries of the Mix.
+func (m *Mix) Entries() []*MixEntry {
+	return m.entries
+}
+
+// Next returns the entry building the next transaction.
+func (m *Mix) Next() *MixEntry {
+	n := m.next.Add(1) - 1
+	return m.entries[m.schedule[n%uint64(len(m.schedule))]]
+}
+
+// smoothSchedule returns one cycle of the smooth weighted round-robin over
+// weights, every index appears in proportion to its weight and the indexes
+// are interleaved instead of coming in bursts of their weight.
+func smoothSchedule(weights []int) []int {
+	// 70/20/10 is scheduled as 7/2/1, the cycle is as short as possible
+	divisor := 0
+	for _, weight := range weights {
+		divisor = gcd(divisor, weight)
+	}
+	weights = slices.Clone(weights)
+	total := 0
+	for i := range weights {
+		weights[i] /= divisor
+		total += weights[i]
+	}
+
+	current := make([]int, len(weights))
+
+	schedule := make([]int, 0, total)
+	for n := 0; n < total; n++ {
+		best := 0
+		for i := range current {
+			current[i] += weights[i]
+			if current[i] > current[best] {
+				best = i
+			}
+		}
+		current[best] -= total
+		schedule = append(schedule, best)
+	}
+	return schedule
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
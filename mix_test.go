@@ -0,0 +1,35 @@
+package tester
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMixNext(t *testing.T) {
+	mix, err := NewMix([]*MixEntry{
+		{Name: "transfer", Weight: 70},
+		{Name: "redeem", Weight: 20},
+		{Name: "batch", Weight: 10},
+	})
+	require.NoError(t, err)
+
+	var names []string
+	counts := make(map[string]int)
+	for n := 0; n < 20; n++ {
+		entry := mix.Next()
+		names = append(names, entry.Name)
+		counts[entry.Name]++
+	}
+	require.Equal(t, map[string]int{"transfer": 14, "redeem": 4, "batch": 2}, counts)
+	// the entries are interleaved, not sent in bursts of their weight
+	require.Equal(t, names[:10], names[10:])
+	require.NotEqual(t, []string{"transfer", "transfer", "transfer"}, names[4:7])
+
+	_, err = NewMix(nil)
+	require.Error(t, err)
+	_, err = NewMix([]*MixEntry{{Name: "transfer", Weight: 0}})
+	require.Error(t, err)
+	_, err = NewMix([]*MixEntry{{Name: "transfer", Weight: 1}, {Name: "transfer", Weight: 2}})
+	require.Error(t, err)
+}
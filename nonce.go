@@ -0,0 +1,292 @@
+package tester
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/holiman/uint256"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+)
+
+// nonceResync keeps the nonce every sender has to use next, and re-signs the
+// transactions whose nonce went out of sync with the node.
+//
+// The transactions of a sender must be sent one at a time, in order.
+type nonceResync struct {
+	eth   *ethclient.Client
+	key   func(addr common.Address) *ecdsa.PrivateKey
+	shift func(addr common.Address, delta int64)
+
+	mu   sync.Mutex
+	next map[common.Address]uint64
+
+	resyncs  atomic.Int64
+	resigned atomic.Int64
+	resent   atomic.Int64
+}
+
+func newNonceResync(
+	eth *ethclient.Client,
+	key func(addr common.Address) *ecdsa.PrivateKey,
+	shift func(addr common.Address, delta int64),
+) *nonceResync {
+	return &nonceResync{
+		eth:   eth,
+		key:   key,
+		shift: shift,
+		next:  make(map[common.Address]uint64),
+	}
+}
+
+// send sends the transaction of payload with the nonce its sender has to use
+// next, and sends it once more when it was rejected for its nonce.
+//
+// The nonces of the generator are moved along with the ones of the sender, so
+// that the following transactions do not have to be re-signed.
+//
+// It returns true when the transaction was sent again.
+func (n *nonceResync) send(ctx context.Context, payload *Payload, send func(payload *Payload) error) bool {
+	first, known := n.nextOf(payload.From)
+	if !known {
+		first = payload.Tx.Nonce()
+	}
+
+	resent := false
+	for {
+		if err := n.prepare(payload); err != nil {
+			slog.Error("failed to re-sign transaction", "sender", payload.From, "err", err)
+		}
+		err := send(payload)
+		if !n.done(ctx, payload, err) || resent {
+			break
+		}
+		resent = true
+		n.resent.Add(1)
+	}
+
+	// every transaction of the generator takes one nonce, anything else is a shift
+	if next, ok := n.nextOf(payload.From); ok && next != first+1 && n.shift != nil {
+		n.shift(payload.From, int64(next)-int64(first+1))
+	}
+	return resent
+}
+
+// prepare re-signs the transaction of payload when its nonce is not the one
+// the sender has to use next.
+func (n *nonceResync) prepare(payload *Payload) error {
+	n.mu.Lock()
+	next, ok := n.next[payload.From]
+	n.mu.Unlock()
+	if !ok || next == payload.Tx.Nonce() {
+		return nil
+	}
+
+	key := n.key(payload.From)
+	if key == nil {
+		return errors.Errorf("no key to re-sign the transactions of %s", payload.From)
+	}
+	tx, err := resignTx(payload.Tx, key, next)
+	if err != nil {
+		return err
+	}
+	txbz, err := tx.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal transaction")
+	}
+	payload.Tx, payload.RawTx = tx, hexutil.Encode(txbz)
+	n.resigned.Add(1)
+	return nil
+}
+
+// done updates the next nonce of the sender from the outcome of a send.
+//
+// A transaction refused by the node leaves its nonce to the next one, the
+// outcome of any other error is unknown and the nonce is resynced with the
+// pending nonce of the sender.
+//
+// It returns true when the transaction was rejected for its nonce, it has then
+// to be prepared and sent again.
+func (n *nonceResync) done(ctx context.Context, payload *Payload, err error) bool {
+	nonce := payload.Tx.Nonce()
+	if err == nil || strings.Contains(err.Error(), "already known") {
+		n.set(payload.From, nonce+1)
+		return false
+	}
+
+	class := ClassifyError(err)
+	switch class {
+	case ErrClassFeeCapTooLow, ErrClassInsufficientFunds, ErrClassTxPoolFull, ErrClassConnection:
+		// the nonce has not been used, the next transaction takes it over
+		n.set(payload.From, nonce)
+		return false
+	}
+
+	pending, perr := n.eth.PendingNonceAt(ctx, payload.From)
+	if perr != nil {
+		slog.Error("failed to resync nonce", "sender", payload.From, "err", perr)
+		n.set(payload.From, nonce)
+		return false
+	}
+	n.resyncs.Add(1)
+	n.set(payload.From, pending)
+	slog.Warn("resync nonce", "sender", payload.From, "nonce", nonce, "pending", pending, "err", class)
+	// a transaction that timed out may still be in the mempool, it is not sent again
+	return (class == ErrClassNonceTooLow || class == ErrClassNonceGap) && pending != nonce
+}
+
+// printResult prints how often the nonces went out of sync.
+func (n *nonceResync) printResult() {
+	if n == nil {
+		return
+	}
+	fmt.Println("Output nonce resync statistics:")
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Resyncs", "Resigned", "Resent"})
+	table.SetAutoFormatHeaders(false)
+	table.Append([]string{
+		strconv.FormatInt(n.resyncs.Load(), 10),
+		strconv.FormatInt(n.resigned.Load(), 10),
+		strconv.FormatInt(n.resent.Load(), 10),
+	})
+	table.Render()
+}
+
+func (n *nonceResync) nextOf(addr common.Address) (uint64, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	next, ok := n.next[addr]
+	return next, ok
+}
+
+func (n *nonceResync) set(addr common.Address, nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.next[addr] = nonce
+}
+
+// resignTx returns a copy of tx with another nonce, signed by key.
+func resignTx(tx *types.Transaction, key *ecdsa.PrivateKey, nonce uint64) (*types.Transaction, error) {
+	return signTx(tx, key, func(f *txFields) {
+		f.nonce = nonce
+	})
+}
+
+// txFields are the fields of a transaction that can be changed before it is signed again.
+type txFields struct {
+	nonce      uint64
+	gasTipCap  *big.Int
+	gasFeeCap  *big.Int // the gas price of the legacy and access list transactions
+	gas        uint64
+	to         *common.Address
+	value      *big.Int
+	data       []byte
+	accessList types.AccessList
+
+	// the blob transactions only
+	blobFeeCap *big.Int
+	blobHashes []common.Hash
+	sidecar    *types.BlobTxSidecar
+}
+
+// signTx returns a copy of tx of the same type, with the fields changed by edit and signed by key.
+func signTx(tx *types.Transaction, key *ecdsa.PrivateKey, edit func(f *txFields)) (*types.Transaction, error) {
+	f := fieldsOf(tx)
+	edit(f)
+
+	data, err := newTxData(tx.Type(), tx.ChainId(), f)
+	if err != nil {
+		return nil, err
+	}
+	return types.SignNewTx(key, types.LatestSignerForChainID(tx.ChainId()), data)
+}
+
+func fieldsOf(tx *types.Transaction) *txFields {
+	return &txFields{
+		nonce:      tx.Nonce(),
+		gasTipCap:  tx.GasTipCap(),
+		gasFeeCap:  tx.GasFeeCap(),
+		gas:        tx.Gas(),
+		to:         tx.To(),
+		value:      tx.Value(),
+		data:       tx.Data(),
+		accessList: tx.AccessList(),
+		blobFeeCap: tx.BlobGasFeeCap(),
+		blobHashes: tx.BlobHashes(),
+		sidecar:    tx.BlobTxSidecar(),
+	}
+}
+
+// newTxData returns the unsigned transaction of type txType made of the fields f.
+func newTxData(txType uint8, chainID *big.Int, f *txFields) (types.TxData, error) {
+	switch txType {
+	case types.LegacyTxType:
+		return &types.LegacyTx{
+			Nonce:    f.nonce,
+			GasPrice: f.gasFeeCap,
+			Gas:      f.gas,
+			To:       f.to,
+			Value:    f.value,
+			Data:     f.data,
+		}, nil
+	case types.AccessListTxType:
+		return &types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      f.nonce,
+			GasPrice:   f.gasFeeCap,
+			Gas:        f.gas,
+			To:         f.to,
+			Value:      f.value,
+			Data:       f.data,
+			AccessList: f.accessList,
+		}, nil
+	case types.DynamicFeeTxType:
+		return &types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      f.nonce,
+			GasTipCap:  f.gasTipCap,
+			GasFeeCap:  f.gasFeeCap,
+			Gas:        f.gas,
+			To:         f.to,
+			Value:      f.value,
+			Data:       f.data,
+			AccessList: f.accessList,
+		}, nil
+	case types.BlobTxType:
+		if f.to == nil {
+			return nil, errors.New("a blob transaction can not create a contract")
+		}
+		blobTx := &types.BlobTx{
+			Nonce:      f.nonce,
+			GasTipCap:  uint256.MustFromBig(f.gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(f.gasFeeCap),
+			Gas:        f.gas,
+			To:         *f.to,
+			Value:      uint256.MustFromBig(f.value),
+			Data:       f.data,
+			AccessList: f.accessList,
+			BlobFeeCap: uint256.MustFromBig(f.blobFeeCap),
+			BlobHashes: f.blobHashes,
+			Sidecar:    f.sidecar,
+		}
+		if chainID != nil {
+			blobTx.ChainID = uint256.MustFromBig(chainID)
+		}
+		return blobTx, nil
+	default:
+		return nil, errors.Errorf("unsupported transaction type %d", txType)
+	}
+}
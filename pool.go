@@ -72,6 +72,13 @@ func (p *Pool) Finish() {
 	p.wg.Wait()
 }
 
+// Tune changes the capacity of the Pool.
+//
+// It has no effect on a Pool created with an unlimited size.
+func (p *Pool) Tune(size int) {
+	p.p.Tune(size)
+}
+
 // Stat returns the statistics of the Pool.
 //
 // It returns a stat struct containing the number of running, waiting, free, and maximum capacity of the Pool,
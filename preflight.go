@@ -0,0 +1,245 @@
+package tester
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PreflightOff skips the pre-flight check.
+	PreflightOff PreflightMode = "off"
+	// PreflightWarn reports the problems found by the pre-flight check and starts anyway.
+	PreflightWarn PreflightMode = "warn"
+	// PreflightStrict refuses to start when the pre-flight check finds a problem.
+	PreflightStrict PreflightMode = "strict"
+)
+
+// PreflightMode represents what happens when the pre-flight check finds a problem.
+type PreflightMode string
+
+// ParsePreflightMode parses the input string and returns the corresponding PreflightMode.
+//
+// Parameters:
+// - mode: The input string to be parsed.
+//
+// Return types:
+// - PreflightMode: The corresponding PreflightMode constant.
+// - error: An error if the input string does not match any of the defined modes.
+func ParsePreflightMode(mode string) (PreflightMode, error) {
+	switch mode {
+	case string(PreflightOff):
+		return PreflightOff, nil
+	case string(PreflightWarn):
+		return PreflightWarn, nil
+	case string(PreflightStrict):
+		return PreflightStrict, nil
+	default:
+		return "", fmt.Errorf("invalid preflight mode: %s", mode)
+	}
+}
+
+// Preflight describes a run whose worst-case cost is checked before it starts.
+type Preflight struct {
+	ChainID       *big.Int
+	Senders       []common.Address
+	RandomSenders bool     // every transaction is sent by a new account, without any balance
+	TxCount       int64    // number of transactions of the run, 0 when it is unbounded
+	BatchSize     uint64   // number of transactions checked when the run is unbounded
+	GasLimit      uint64   // 0 when the gas limit is estimated per transaction, the costs are then lower bounds
+	GasFeeCap     *big.Int // nil when the fee cap comes from the gas price oracle
+	GasTipCap     *big.Int // nil when the tip cap comes from the gas price oracle
+	Value         *big.Int
+	BlobGas       uint64   // blob gas of every transaction, 0 without blobs
+	BlobFeeCap    *big.Int // nil when the blob fee cap follows the blob base fee
+}
+
+type preflightAccount struct {
+	address common.Address
+	txs     int64
+	balance *big.Int
+	cost    *big.Int
+}
+
+// PreflightReport is the outcome of a pre-flight check.
+type PreflightReport struct {
+	chainID   *big.Int
+	baseFee   *big.Int
+	gasLimit  uint64
+	gasFeeCap *big.Int
+	txCount   int64
+	bounded   bool
+	costPerTx *big.Int
+	accounts  []*preflightAccount
+	problems  []string
+}
+
+// Check fetches the chain ID, the base fee and the balances of the senders, and
+// compares the balances with the worst-case cost of the run.
+//
+// Parameters:
+// - ctx: the context of the requests.
+// - eth: the client used to fetch the chain state.
+//
+// Returns:
+// - *PreflightReport: the report of the check.
+// - error: An error if the chain state can not be fetched.
+func (p *Preflight) Check(ctx context.Context, eth *ethclient.Client) (*PreflightReport, error) {
+	r := &PreflightReport{
+		gasLimit: p.GasLimit,
+		txCount:  p.TxCount,
+		bounded:  p.TxCount > 0,
+	}
+
+	chainID, err := eth.ChainID(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch the chain ID")
+	}
+	r.chainID = chainID
+	if p.ChainID != nil && p.ChainID.Cmp(chainID) != 0 {
+		r.problems = append(r.problems, fmt.Sprintf("chain ID %s is configured but the endpoint serves chain %s", p.ChainID, chainID))
+	}
+
+	head, err := eth.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch the latest header")
+	}
+	r.baseFee = head.BaseFee
+
+	r.gasFeeCap = p.GasFeeCap
+	if r.gasFeeCap == nil {
+		// what bind.TransactOpts uses when no fee cap is given
+		tipCap := p.GasTipCap
+		if tipCap == nil {
+			if tipCap, err = eth.SuggestGasTipCap(ctx); err != nil {
+				return nil, errors.Wrap(err, "failed to suggest the gas tip cap")
+			}
+		}
+		r.gasFeeCap = new(big.Int).Set(tipCap)
+		if head.BaseFee != nil {
+			r.gasFeeCap.Add(r.gasFeeCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+		}
+	} else if head.BaseFee != nil && r.gasFeeCap.Cmp(head.BaseFee) < 0 {
+		r.problems = append(r.problems, fmt.Sprintf("gas fee cap %s is below the base fee %s", r.gasFeeCap, head.BaseFee))
+	}
+
+	gasLimit := p.GasLimit
+	if gasLimit == 0 {
+		// the gas of a transaction is only known once it is estimated, take the
+		// lowest possible one so that a reported shortfall is certain, the
+		// costs are then lower bounds
+		gasLimit = params.TxGas
+	} else if gasLimit > head.GasLimit {
+		r.problems = append(r.problems, fmt.Sprintf("gas limit %d is above the block gas limit %d", gasLimit, head.GasLimit))
+	}
+	r.costPerTx = new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), r.gasFeeCap)
+	if p.Value != nil {
+		r.costPerTx.Add(r.costPerTx, p.Value)
+	}
+	if p.BlobGas > 0 {
+		if head.ExcessBlobGas == nil {
+			r.problems = append(r.problems, "the transactions carry blobs but the chain does not accept them")
+		}
+		blobFeeCap := p.BlobFeeCap
+		if blobFeeCap == nil && head.ExcessBlobGas != nil {
+			// what NewBlobTx uses when no blob fee cap is given
+			blobFeeCap = new(big.Int).Mul(eip4844.CalcBlobFee(*head.ExcessBlobGas), big.NewInt(2))
+		}
+		if blobFeeCap != nil {
+			r.costPerTx.Add(r.costPerTx, new(big.Int).Mul(new(big.Int).SetUint64(p.BlobGas), blobFeeCap))
+		}
+	}
+
+	if p.RandomSenders {
+		if r.costPerTx.Sign() > 0 {
+			r.problems = append(r.problems, fmt.Sprintf("every transaction is sent by a new account without any balance, but costs up to %s", r.costPerTx))
+		}
+		return r, nil
+	}
+
+	txCount := p.TxCount
+	if !r.bounded {
+		txCount = int64(p.BatchSize)
+	}
+	for i, sender := range p.Senders {
+		// the transactions are spread evenly over the senders
+		txs := txCount / int64(len(p.Senders))
+		if int64(i) < txCount%int64(len(p.Senders)) {
+			txs++
+		}
+		balance, err := eth.BalanceAt(ctx, sender, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch the balance of %s", sender)
+		}
+		account := &preflightAccount{
+			address: sender,
+			txs:     txs,
+			balance: balance,
+			cost:    new(big.Int).Mul(r.costPerTx, big.NewInt(txs)),
+		}
+		if balance.Cmp(account.cost) < 0 {
+			r.problems = append(r.problems, fmt.Sprintf("%s holds %s but its %d transactions cost up to %s", sender, balance, txs, account.cost))
+		}
+		r.accounts = append(r.accounts, account)
+	}
+	return r, nil
+}
+
+// OK returns whether the check found no problem.
+func (r *PreflightReport) OK() bool {
+	return len(r.problems) == 0
+}
+
+// Print prints the chain state, the worst-case cost of every sender and the problems found.
+func (r *PreflightReport) Print() {
+	fmt.Println("Output preflight statistics:")
+
+	baseFee, gasLimit, txCount := "-", "unknown, at least "+strconv.FormatUint(params.TxGas, 10), "unbounded, one batch is checked"
+	if r.baseFee != nil {
+		baseFee = r.baseFee.String()
+	}
+	if r.gasLimit > 0 {
+		gasLimit = strconv.FormatUint(r.gasLimit, 10)
+	}
+	if r.bounded {
+		txCount = strconv.FormatInt(r.txCount, 10)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ChainID", "BaseFee", "GasFeeCap", "GasLimit", "Txs", "CostPerTx"})
+	table.SetAutoFormatHeaders(false)
+	table.Append([]string{r.chainID.String(), baseFee, r.gasFeeCap.String(), gasLimit, txCount, r.costPerTx.String()})
+	table.Render()
+
+	if len(r.accounts) > 0 {
+		table = tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Account", "Txs", "Balance", "Cost", "Sufficient"})
+		table.SetAutoFormatHeaders(false)
+		for _, account := range r.accounts {
+			table.Append([]string{
+				account.address.Hex(),
+				strconv.FormatInt(account.txs, 10),
+				account.balance.String(),
+				account.cost.String(),
+				strconv.FormatBool(account.balance.Cmp(account.cost) >= 0),
+			})
+		}
+		table.Render()
+	}
+
+	if r.gasLimit == 0 {
+		fmt.Println("Preflight note: the gas of the transactions is estimated when they are built, the costs are lower bounds")
+	}
+	for _, problem := range r.problems {
+		fmt.Println("Preflight problem:", problem)
+	}
+}
@@ -0,0 +1,156 @@
+package tester
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Step represents jumping to the stage load as soon as the stage begins.
+	Step RampShape = "step"
+	// Linear represents ramping linearly from the previous stage load to the
+	// stage load over the stage duration.
+	Linear RampShape = "linear"
+)
+
+// RampShape represents how a stage moves from the previous load to its own.
+type RampShape string
+
+// Stage represents one step of a load profile.
+type Stage struct {
+	Name     string
+	Duration time.Duration
+	Users    int
+	Rate     float64
+	Shape    RampShape
+}
+
+// LoadProfile is an ordered list of stages the Transactor follows.
+type LoadProfile []Stage
+
+// ParseLoadProfile parses a load profile, one stage per element.
+//
+// A stage is a list of `key=value` fields separated by `:`, for example
+// `name=warmup:duration=1m:rate=500:shape=linear`. The supported keys are
+// `name`, `duration` (required), `users`, `rate` and `shape` (`step` or
+// `linear`, default `step`). Each stage must set `users`, `rate` or both.
+//
+// Parameters:
+// - stages: the stage definitions.
+//
+// Return types:
+// - LoadProfile: the parsed profile.
+// - error: An error if any stage is invalid.
+func ParseLoadProfile(stages []string) (LoadProfile, error) {
+	profile := make(LoadProfile, 0, len(stages))
+	for i, def := range stages {
+		stage := Stage{
+			Name:  fmt.Sprintf("stage-%d", i),
+			Shape: Step,
+		}
+		for _, field := range strings.Split(def, ":") {
+			key, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid stage field: %s", field)
+			}
+
+			var err error
+			switch key {
+			case "name":
+				stage.Name = value
+			case "duration":
+				stage.Duration, err = time.ParseDuration(value)
+			case "users":
+				stage.Users, err = strconv.Atoi(value)
+			case "rate":
+				stage.Rate, err = strconv.ParseFloat(value, 64)
+			case "shape":
+				stage.Shape = RampShape(value)
+				if stage.Shape != Step && stage.Shape != Linear {
+					err = fmt.Errorf("invalid ramp shape: %s", value)
+				}
+			default:
+				err = fmt.Errorf("unknown stage field: %s", key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid stage %q: %w", def, err)
+			}
+		}
+
+		if stage.Duration <= 0 {
+			return nil, fmt.Errorf("invalid stage %q: duration must be greater than 0", def)
+		}
+		if stage.Users <= 0 && stage.Rate <= 0 {
+			return nil, fmt.Errorf("invalid stage %q: users or rate is required", def)
+		}
+		profile = append(profile, stage)
+	}
+	return profile, nil
+}
+
+// Duration returns the total duration of the profile.
+func (p LoadProfile) Duration() (total time.Duration) {
+	for _, stage := range p {
+		total += stage.Duration
+	}
+	return
+}
+
+// HasRate reports whether any stage of the profile sets a target rate.
+func (p LoadProfile) HasRate() bool {
+	for _, stage := range p {
+		if stage.Rate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// maxUsers returns the largest number of users a stage of the profile sets, or
+// 0 when no stage sets users.
+func (p LoadProfile) maxUsers() (users int) {
+	for _, stage := range p {
+		users = max(users, stage.Users)
+	}
+	return
+}
+
+// at returns the index of the stage running at elapsed, together with the
+// number of users and the rate to apply at that moment. A zero users or rate
+// means the stage does not change it.
+func (p LoadProfile) at(elapsed time.Duration) (idx int, users int, rate float64) {
+	var (
+		begin     time.Duration
+		prevUsers int
+		prevRate  float64
+	)
+	for idx = 0; idx < len(p); idx++ {
+		stage := p[idx]
+		if elapsed < begin+stage.Duration || idx == len(p)-1 {
+			users, rate = stage.Users, stage.Rate
+			if stage.Shape == Linear {
+				progress := float64(elapsed-begin) / float64(stage.Duration)
+				progress = min(max(progress, 0), 1)
+				if users > 0 {
+					users = prevUsers + int(float64(users-prevUsers)*progress)
+					users = max(users, 1)
+				}
+				if rate > 0 {
+					rate = prevRate + (rate-prevRate)*progress
+					rate = max(rate, 1)
+				}
+			}
+			return idx, users, rate
+		}
+		begin += stage.Duration
+		if stage.Users > 0 {
+			prevUsers = stage.Users
+		}
+		if stage.Rate > 0 {
+			prevRate = stage.Rate
+		}
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package tester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLoadProfile(t *testing.T) {
+	profile, err := ParseLoadProfile([]string{
+		"name=ramp:duration=10s:rate=100:shape=linear",
+		"duration=20s:rate=100",
+		"name=spike:duration=5s:rate=1000:users=20",
+	})
+	require.NoError(t, err)
+	require.Len(t, profile, 3)
+	require.Equal(t, "stage-1", profile[1].Name)
+	require.Equal(t, 35*time.Second, profile.Duration())
+	require.True(t, profile.HasRate())
+	require.Equal(t, 20, profile.maxUsers())
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		idx     int
+		users   int
+		rate    float64
+	}{
+		{"ramp begin", 0, 0, 0, 1},
+		{"ramp middle", 5 * time.Second, 0, 0, 50},
+		{"plateau", 15 * time.Second, 1, 0, 100},
+		{"spike", 31 * time.Second, 2, 20, 1000},
+		{"after end", time.Minute, 2, 20, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx, users, rate := profile.at(tt.elapsed)
+			require.Equal(t, tt.idx, idx)
+			require.Equal(t, tt.users, users)
+			require.InDelta(t, tt.rate, rate, 0.001)
+		})
+	}
+
+	for _, invalid := range []string{"duration=10s", "rate=10", "duration=1s:rate=1:shape=curve", "duration=1s:qps=1"} {
+		_, err := ParseLoadProfile([]string{invalid})
+		require.Error(t, err, invalid)
+	}
+}
@@ -11,6 +11,11 @@ type Queue[T any] struct {
 	q *v2.Queue[T]
 	p *Pool
 	l sync.Mutex
+
+	// iter serializes the iterations, busy is the number of elements taken
+	// out of the queue by the running one
+	iter sync.Mutex
+	busy int
 }
 
 // NewQueue creates a new Queue instance.
@@ -42,16 +47,16 @@ func (q *Queue[T]) Add(v T) {
 //	If the function returns true, the element will be removed from the queue. If the function returns false,
 //	the element will not be removed from the queue.
 func (q *Queue[T]) Iterate(f func(T) bool) {
-	q.l.Lock()
-	defer q.l.Unlock()
+	q.iter.Lock()
+	defer q.iter.Unlock()
 
-	length := q.Length()
-	for i := 0; i < length; i++ {
-		element := q.q.Peek()
-		if f(element) {
-			_ = q.q.Remove()
+	var keep []T
+	for _, element := range q.take() {
+		if !f(element) {
+			keep = append(keep, element)
 		}
 	}
+	q.putBack(keep)
 }
 
 // IterateParallel iterates over the elements in the queue in parallel and applies a function to each element.
@@ -59,25 +64,60 @@ func (q *Queue[T]) Iterate(f func(T) bool) {
 // The function `f` is applied to each element in the queue. If the function returns `true`, the element is removed from the queue.
 // The function does not guarantee the order in which the elements are processed.
 func (q *Queue[T]) IterateParallel(f func(T) bool) {
-	q.l.Lock()
-	defer q.l.Unlock()
+	q.iter.Lock()
+	defer q.iter.Unlock()
 
-	length := q.Length()
-	for i := 0; i < length; i++ {
-		element := q.q.Peek()
+	var (
+		mu   sync.Mutex
+		keep []T
+	)
+	for _, element := range q.take() {
+		element := element
 		q.p.Submit(func() {
-			if f(element) {
-				_ = q.q.Remove()
+			if !f(element) {
+				mu.Lock()
+				keep = append(keep, element)
+				mu.Unlock()
 			}
 		})
 	}
 	q.p.Finish()
+	q.putBack(keep)
+}
+
+// take removes all the elements of the queue, they are still counted by
+// Length until putBack is called. The elements added in between are kept
+// in the queue, so Add never waits for an iteration.
+func (q *Queue[T]) take() []T {
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	elements := make([]T, 0, q.q.Length())
+	for q.q.Length() > 0 {
+		elements = append(elements, q.q.Remove())
+	}
+	q.busy = len(elements)
+	return elements
+}
+
+// putBack adds the elements kept by an iteration back to the queue.
+func (q *Queue[T]) putBack(elements []T) {
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	for _, element := range elements {
+		q.q.Add(element)
+	}
+	q.busy = 0
 }
 
 func (q *Queue[T]) Length() int {
-	return q.q.Length()
+	q.l.Lock()
+	defer q.l.Unlock()
+
+	return q.q.Length() + q.busy
 }
 
 func (q *Queue[T]) IsEmpty() bool {
-	return q.q.Length() == 0
+	return q.Length() == 0
 }
@@ -0,0 +1,133 @@
+package tester
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+)
+
+// Sample is a snapshot of the Transactor taken by the Reporter.
+type Sample struct {
+	Time          time.Time `csv:"time" json:"time"`
+	Elapsed       float64   `csv:"elapsed" json:"elapsed"`
+	Sent          int64     `csv:"sent" json:"sent"`
+	Acked         int64     `csv:"acked" json:"acked"`
+	Failed        int64     `csv:"failed" json:"failed"`
+	SentRate      int64     `csv:"sent_per_second" json:"sent_per_second"`
+	AckedRate     int64     `csv:"acked_per_second" json:"acked_per_second"`
+	FailedRate    int64     `csv:"failed_per_second" json:"failed_per_second"`
+	InFlight      int       `csv:"in_flight" json:"in_flight"`
+	PoolWaiting   int       `csv:"pool_waiting" json:"pool_waiting"`
+	BatchQueue    int       `csv:"batch_queue" json:"batch_queue"`
+	TallyQueue    int       `csv:"tally_queue" json:"tally_queue"`
+	VerifyBacklog int       `csv:"verify_backlog" json:"verify_backlog"`
+}
+
+// Reporter samples the Transactor every second and writes the samples to a
+// CSV or JSON lines time series.
+type Reporter struct {
+	path     string
+	snapshot func() *Sample
+	file     *os.File
+	csv      *gocsv.SafeCSVWriter
+	json     *json.Encoder
+	begin    time.Time
+	last     *Sample
+	written  int
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewReporter creates a new Reporter writing to path.
+//
+// The samples are written as JSON lines if path ends with `.jsonl`, and as
+// CSV otherwise.
+//
+// Parameters:
+// - path: the time series file path.
+// - snapshot: a function returning the current state of the run.
+//
+// Returns:
+// - a pointer to the Reporter.
+// - error: An error if the file can not be created.
+func NewReporter(path string, snapshot func() *Sample) (*Reporter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Reporter{
+		path:     path,
+		snapshot: snapshot,
+		file:     file,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if strings.EqualFold(filepath.Ext(path), ".jsonl") {
+		r.json = json.NewEncoder(file)
+	} else {
+		r.csv = gocsv.NewSafeCSVWriter(csv.NewWriter(file))
+	}
+	return r, nil
+}
+
+// Start samples the run every second until Stop is called.
+func (r *Reporter) Start() {
+	defer close(r.done)
+
+	r.begin = time.Now()
+	tick := time.NewTicker(1 * time.Second)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.C:
+			r.sample()
+		case <-r.stop:
+			r.sample()
+			return
+		}
+	}
+}
+
+// Stop takes a last sample and closes the time series file.
+func (r *Reporter) Stop() {
+	close(r.stop)
+	<-r.done
+	if err := r.file.Close(); err != nil {
+		slog.Error("failed to close time series", "path", r.path, "err", err)
+	}
+}
+
+func (r *Reporter) sample() {
+	s := r.snapshot()
+	s.Elapsed = s.Time.Sub(r.begin).Seconds()
+	if r.last != nil {
+		s.SentRate = s.Sent - r.last.Sent
+		s.AckedRate = s.Acked - r.last.Acked
+		s.FailedRate = s.Failed - r.last.Failed
+	} else {
+		s.SentRate, s.AckedRate, s.FailedRate = s.Sent, s.Acked, s.Failed
+	}
+	r.last = s
+
+	var err error
+	switch {
+	case r.json != nil:
+		err = r.json.Encode(s)
+	case r.written == 0:
+		err = gocsv.MarshalCSV([]*Sample{s}, r.csv)
+	default:
+		err = gocsv.MarshalCSVWithoutHeaders([]*Sample{s}, r.csv)
+	}
+	if err != nil {
+		slog.Error("failed to write time series", "path", r.path, "err", err)
+		return
+	}
+	r.written++
+}
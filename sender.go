@@ -0,0 +1,103 @@
+package tester
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+)
+
+// Sender is an account sending transactions, it keeps track of its own nonce.
+type Sender struct {
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+
+	mu    sync.Mutex
+	nonce uint64
+}
+
+// NextNonce returns the nonce of the next transaction of the Sender.
+func (s *Sender) NextNonce() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nonce := s.nonce
+	s.nonce++
+	return nonce
+}
+
+// shift moves the nonce of the next transaction of the Sender by delta.
+func (s *Sender) shift(delta int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nonce = uint64(int64(s.nonce) + delta)
+}
+
+// SenderPool spreads transactions over a set of accounts.
+type SenderPool struct {
+	senders []*Sender
+	index   map[common.Address]*Sender
+	next    atomic.Uint64
+}
+
+// NewSenderPool creates a new SenderPool.
+//
+// The nonce of every account is initialized with its pending nonce.
+//
+// Parameters:
+// - ctx: the context of the nonce requests.
+// - eth: an instance of ethclient.Client used to fetch the nonces.
+// - keys: the private keys of the accounts.
+//
+// Returns:
+// - a pointer to the SenderPool.
+// - error: An error if a nonce can not be fetched.
+func NewSenderPool(ctx context.Context, eth *ethclient.Client, keys []*ecdsa.PrivateKey) (*SenderPool, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("sender pool requires at least one key")
+	}
+	p := &SenderPool{
+		senders: make([]*Sender, 0, len(keys)),
+		index:   make(map[common.Address]*Sender, len(keys)),
+	}
+	for _, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		if _, ok := p.index[addr]; ok {
+			continue
+		}
+		nonce, err := eth.PendingNonceAt(ctx, addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch the nonce of %s", addr)
+		}
+		sender := &Sender{
+			Key:     key,
+			Address: addr,
+			nonce:   nonce,
+		}
+		p.senders = append(p.senders, sender)
+		p.index[addr] = sender
+	}
+	return p, nil
+}
+
+// Next returns the Sender of the next transaction, in turn.
+func (p *SenderPool) Next() *Sender {
+	n := p.next.Add(1) - 1
+	return p.senders[n%uint64(len(p.senders))]
+}
+
+// Get returns the Sender with the given address, or nil if it is not in the pool.
+func (p *SenderPool) Get(addr common.Address) *Sender {
+	return p.index[addr]
+}
+
+// Senders returns all the senders of the pool.
+func (p *SenderPool) Senders() []*Sender {
+	return p.senders
+}
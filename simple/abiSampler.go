@@ -0,0 +1,411 @@
+package simple
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pkg/errors"
+
+	tester "github.com/dreamer-zq/evm-tester"
+)
+
+var _ Contract = &ABISampler{}
+
+// ABISampler is a struct that implements the Contract interface for any
+// contract, from its ABI loaded at runtime.
+type ABISampler struct {
+	contractAddr common.Address
+	abi          abi.ABI
+	bin          []byte
+}
+
+// LoadABISampler creates an ABISampler from an ABI file and an optional bytecode file.
+//
+// Parameters:
+// - abiPath: the ABI JSON file, or a Hardhat/Foundry artifact holding an `abi` field.
+// - binPath: the hex bytecode file, empty to use the `bytecode` of the artifact.
+//
+// Returns:
+// - *ABISampler: the sampler, it can only deploy the contract when a bytecode is found.
+// - error: An error if a file can not be read or parsed.
+func LoadABISampler(abiPath, binPath string) (*ABISampler, error) {
+	abiJSON, err := os.ReadFile(abiPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var artifact struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode json.RawMessage `json:"bytecode"`
+	}
+	var bin string
+	if trimmed := bytes.TrimSpace(abiJSON); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &artifact); err != nil {
+			return nil, errors.Wrapf(err, "invalid artifact %s", abiPath)
+		}
+		abiJSON = artifact.ABI
+		bin = artifactBytecode(artifact.Bytecode)
+	}
+
+	parsed, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid abi %s", abiPath)
+	}
+
+	if binPath != "" {
+		binHex, err := os.ReadFile(binPath)
+		if err != nil {
+			return nil, err
+		}
+		bin = string(binHex)
+	}
+	var code []byte
+	if bin = strings.TrimSpace(bin); bin != "" {
+		if code, err = hexutil.Decode("0x" + strings.TrimPrefix(bin, "0x")); err != nil {
+			return nil, errors.Wrap(err, "invalid bytecode")
+		}
+	}
+
+	return &ABISampler{
+		abi: parsed,
+		bin: code,
+	}, nil
+}
+
+// artifactBytecode returns the bytecode of an artifact, Hardhat stores it as a
+// string and Foundry as an object with an `object` field.
+func artifactBytecode(raw json.RawMessage) string {
+	var bin string
+	if json.Unmarshal(raw, &bin) == nil {
+		return bin
+	}
+	var object struct {
+		Object string `json:"object"`
+	}
+	if json.Unmarshal(raw, &object) == nil {
+		return object.Object
+	}
+	return ""
+}
+
+// SetContractAddr sets the contract address for the ABISampler.
+func (as *ABISampler) SetContractAddr(contractAddr common.Address) {
+	as.contractAddr = contractAddr
+}
+
+// GenTxBuilder generates a CreateTx function calling method with params.
+//
+// It takes the client, the method name and its string params as parameters.
+// It returns a CreateTx function and an error.
+func (as *ABISampler) GenTxBuilder(conn *ethclient.Client, method string, params []string) (tester.CreateTx, error) {
+	methodMap, err := as.MethodMap(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := methodMap[method]
+	if !ok {
+		return nil, errors.New("invalid method")
+	}
+	p, err := m.FormatParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return m.GenTx(opts, p...)
+	}, nil
+}
+
+// Deploy deploys the contract with the constructor params.
+//
+// It takes an authenticated transaction options, a contract backend and the constructor params as parameters.
+// It returns the address of the deployed contract and an error if the deployment fails.
+func (as *ABISampler) Deploy(auth *bind.TransactOpts, backend bind.ContractBackend, params []string) (common.Address, error) {
+	if len(as.bin) == 0 {
+		return common.Address{}, errors.New("no bytecode to deploy the contract")
+	}
+	args, err := ParseArgs(as.abi.Constructor.Inputs, params)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	contractAddr, _, _, err := bind.DeployContract(auth, as.abi, as.bin, backend, args...)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to deploy contract")
+	}
+	return contractAddr, nil
+}
+
+// MethodMap returns every method of the contract changing its state, the view
+// and pure ones are left out.
+func (as *ABISampler) MethodMap(conn *ethclient.Client) (map[string]Method, error) {
+	contract := bind.NewBoundContract(as.contractAddr, as.abi, conn, conn, conn)
+
+	methods := make(map[string]Method)
+	for name, method := range as.abi.Methods {
+		if method.IsConstant() {
+			continue
+		}
+		methods[name] = ABISamplerMethod{contract, method}
+	}
+	return methods, nil
+}
+
+// ABISamplerMethod is a struct that implements the Method interface for any contract method.
+type ABISamplerMethod struct {
+	contract *bind.BoundContract
+	method   abi.Method
+}
+
+// FormatParams parses the params into the types of the method inputs.
+//
+// It takes in a slice of strings called params and returns a slice of interfaces and an error.
+func (m ABISamplerMethod) FormatParams(params []string) ([]interface{}, error) {
+	return ParseArgs(m.method.Inputs, params)
+}
+
+// GenTx generates a transaction calling the method with params.
+//
+// It takes the transaction options and the formatted params.
+// It returns a transaction object and an error.
+func (m ABISamplerMethod) GenTx(opts *bind.TransactOpts, params ...interface{}) (*types.Transaction, error) {
+	return m.contract.Transact(opts, m.method.Name, params...)
+}
+
+// Display returns the string representation of the method.
+func (m ABISamplerMethod) Display() string {
+	return m.method.String()
+}
+
+// ParseArgs parses one string param per ABI argument into the Go value the ABI packs.
+//
+// The arrays, slices and tuples are given in JSON, eg: `["0x01","0x02"]` or
+// `{"to":"0x01","amount":"10"}`, the integers in decimal or in hex with a 0x prefix.
+//
+// Parameters:
+// - args: the ABI arguments.
+// - params: the string params, one per argument.
+//
+// Returns:
+// - []interface{}: the values of the arguments.
+// - error: An error if a param does not match the type of its argument.
+func ParseArgs(args abi.Arguments, params []string) ([]interface{}, error) {
+	if len(params) != len(args) {
+		return nil, fmt.Errorf("invalid contract params: %d given, %d expected", len(params), len(args))
+	}
+
+	values := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		value, err := ParseArg(arg.Type, params[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid param %q", arg.Name)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// ParseArg parses a string param into the Go value of the ABI type typ.
+func ParseArg(typ abi.Type, param string) (interface{}, error) {
+	param = strings.TrimSpace(param)
+	switch typ.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(param) {
+			return nil, fmt.Errorf("invalid address: %s", param)
+		}
+		return common.HexToAddress(param), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(param)
+	case abi.StringTy:
+		return param, nil
+	case abi.IntTy, abi.UintTy:
+		return parseInt(typ, param)
+	case abi.BytesTy:
+		return hexutil.Decode(param)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(param)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != typ.Size {
+			return nil, fmt.Errorf("invalid bytes%d: %s", typ.Size, param)
+		}
+		value := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(b))
+		return value.Interface(), nil
+	case abi.SliceTy, abi.ArrayTy:
+		elems, err := splitJSON(param)
+		if err != nil {
+			return nil, err
+		}
+		var value reflect.Value
+		if typ.T == abi.SliceTy {
+			value = reflect.MakeSlice(typ.GetType(), len(elems), len(elems))
+		} else {
+			if len(elems) != typ.Size {
+				return nil, fmt.Errorf("invalid array of %d elements: %s", typ.Size, param)
+			}
+			value = reflect.New(typ.GetType()).Elem()
+		}
+		for i, elem := range elems {
+			v, err := ParseArg(*typ.Elem, elem)
+			if err != nil {
+				return nil, err
+			}
+			value.Index(i).Set(reflect.ValueOf(v))
+		}
+		return value.Interface(), nil
+	case abi.TupleTy:
+		elems, err := splitTuple(typ, param)
+		if err != nil {
+			return nil, err
+		}
+		value := reflect.New(typ.GetType()).Elem()
+		for i, elem := range elems {
+			v, err := ParseArg(*typ.TupleElems[i], elem)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid field %q", typ.TupleRawNames[i])
+			}
+			value.Field(i).Set(reflect.ValueOf(v))
+		}
+		return value.Interface(), nil
+	default:
+		return nil, fmt.Errorf("unsupported abi type: %s", typ)
+	}
+}
+
+// parseInt parses an integer into *big.Int, or into the sized Go integer of the
+// types up to 64 bits.
+func parseInt(typ abi.Type, param string) (interface{}, error) {
+	n, ok := new(big.Int).SetString(param, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer: %s", param)
+	}
+	if typ.T == abi.UintTy && (n.Sign() < 0 || n.BitLen() > typ.Size) {
+		return nil, fmt.Errorf("%s out of range of %s", param, typ)
+	}
+	if typ.T == abi.IntTy {
+		bound := new(big.Int).Lsh(big.NewInt(1), uint(typ.Size-1))
+		if n.Cmp(bound) >= 0 || n.Cmp(new(big.Int).Neg(bound)) < 0 {
+			return nil, fmt.Errorf("%s out of range of %s", param, typ)
+		}
+	}
+
+	goType := typ.GetType()
+	switch goType.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.ValueOf(n.Int64()).Convert(goType).Interface(), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.ValueOf(n.Uint64()).Convert(goType).Interface(), nil
+	default:
+		return n, nil
+	}
+}
+
+// splitJSON splits a JSON array into the string params of its elements.
+func splitJSON(param string) ([]string, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal([]byte(param), &raws); err != nil {
+		return nil, errors.Wrapf(err, "invalid JSON array: %s", param)
+	}
+	elems := make([]string, 0, len(raws))
+	for _, raw := range raws {
+		elems = append(elems, rawParam(raw))
+	}
+	return elems, nil
+}
+
+// splitTuple splits a tuple given as a JSON array, or as a JSON object keyed by
+// the field names, into the string params of its fields.
+func splitTuple(typ abi.Type, param string) ([]string, error) {
+	if !strings.HasPrefix(param, "{") {
+		elems, err := splitJSON(param)
+		if err != nil {
+			return nil, err
+		}
+		if len(elems) != len(typ.TupleElems) {
+			return nil, fmt.Errorf("invalid tuple of %d fields: %s", len(typ.TupleElems), param)
+		}
+		return elems, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(param), &fields); err != nil {
+		return nil, errors.Wrapf(err, "invalid JSON object: %s", param)
+	}
+	elems := make([]string, 0, len(typ.TupleRawNames))
+	for _, name := range typ.TupleRawNames {
+		raw, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("missing field %q: %s", name, param)
+		}
+		elems = append(elems, rawParam(raw))
+	}
+	return elems, nil
+}
+
+// rawParam returns a JSON string unquoted, and any other JSON value as it is.
+func rawParam(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// SplitParams splits comma separated params, the commas nested in a JSON array,
+// object or string do not split, eg: `1,["0x01","0x02"]` is `1` and `["0x01","0x02"]`.
+//
+// A param quoted as a whole is unquoted as a CSV field, the way the params were
+// always read, eg: `"a,b"` is `a,b` and `"say ""hi"""` is `say "hi"`.
+func SplitParams(values []string) []string {
+	var params []string
+	for _, value := range values {
+		depth, quoted, start := 0, false, 0
+		for i := 0; i < len(value); i++ {
+			switch c := value[i]; {
+			case quoted && c == '\\':
+				i++
+			case c == '"':
+				quoted = !quoted
+			case quoted:
+			case c == '[' || c == '{':
+				depth++
+			case c == ']' || c == '}':
+				depth--
+			case c == ',' && depth == 0:
+				params = append(params, unquoteParam(value[start:i]))
+				start = i + 1
+			}
+		}
+		params = append(params, unquoteParam(value[start:]))
+	}
+	return params
+}
+
+// unquoteParam strips the quotes of a param quoted as a whole, the doubled
+// quotes inside are single ones. The other params are returned unchanged.
+func unquoteParam(param string) string {
+	if len(param) < 2 || param[0] != '"' || param[len(param)-1] != '"' {
+		return param
+	}
+	inner := param[1 : len(param)-1]
+	if strings.Contains(strings.ReplaceAll(inner, `""`, ""), `"`) {
+		// a lone quote ends the quoted part before the end of the param
+		return param
+	}
+	return strings.ReplaceAll(inner, `""`, `"`)
+}
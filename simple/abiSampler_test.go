@@ -0,0 +1,87 @@
+package simple
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `[{"type":"function","name":"f","stateMutability":"nonpayable","outputs":[],"inputs":[
+	{"name":"to","type":"address"},
+	{"name":"small","type":"uint8"},
+	{"name":"signed","type":"int64"},
+	{"name":"amount","type":"uint256"},
+	{"name":"ok","type":"bool"},
+	{"name":"data","type":"bytes"},
+	{"name":"id","type":"bytes4"},
+	{"name":"ids","type":"uint256[]"},
+	{"name":"pair","type":"address[2]"},
+	{"name":"order","type":"tuple","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"uint128[]"}]}
+]}]`
+
+func TestParseArgs(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(testABI))
+	require.NoError(t, err)
+	method := parsed.Methods["f"]
+
+	addr := "0x5FbDB2315678afecb367f032d93F642f64180aa3"
+	params := SplitParams([]string{
+		addr + ",255,-7,0x10,true,0xabcd,0x01020304",
+		`[1,"2"]`,
+		`["` + addr + `","` + addr + `"],{"maker":"` + addr + `","amounts":[3]}`,
+	})
+	require.Len(t, params, 10)
+
+	values, err := ParseArgs(method.Inputs, params)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress(addr), values[0])
+	require.Equal(t, uint8(255), values[1])
+	require.Equal(t, int64(-7), values[2])
+	require.Equal(t, big.NewInt(16), values[3])
+	require.Equal(t, true, values[4])
+	require.Equal(t, []byte{0xab, 0xcd}, values[5])
+	require.Equal(t, [4]byte{1, 2, 3, 4}, values[6])
+	require.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, values[7])
+	require.Equal(t, [2]common.Address{common.HexToAddress(addr), common.HexToAddress(addr)}, values[8])
+
+	_, err = method.Inputs.Pack(values...)
+	require.NoError(t, err)
+
+	params[9] = `["` + addr + `",[3]]`
+	values, err = ParseArgs(method.Inputs, params)
+	require.NoError(t, err)
+	_, err = method.Inputs.Pack(values...)
+	require.NoError(t, err)
+
+	params[1] = "256"
+	_, err = ParseArgs(method.Inputs, params)
+	require.Error(t, err)
+
+	_, err = ParseArgs(method.Inputs, params[:9])
+	require.Error(t, err)
+}
+
+func TestSplitParams(t *testing.T) {
+	testCases := []struct {
+		name   string
+		values []string
+		want   []string
+	}{
+		{"comma separated", []string{"a,b", "c"}, []string{"a", "b", "c"}},
+		{"quoted comma", []string{`"a,b",c`}, []string{"a,b", "c"}},
+		{"doubled quotes", []string{`"say ""hi""",1`}, []string{`say "hi"`, "1"}},
+		{"empty quoted", []string{`"",1`}, []string{"", "1"}},
+		{"json array", []string{`1,["0x01","0x02"]`}, []string{"1", `["0x01","0x02"]`}},
+		{"json object", []string{`{"a":"x,y"},2`}, []string{`{"a":"x,y"}`, "2"}},
+		{"partly quoted", []string{`"a"b"c"`}, []string{`"a"b"c"`}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, SplitParams(tc.values))
+		})
+	}
+}
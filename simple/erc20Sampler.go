@@ -0,0 +1,290 @@
+package simple
+
+import (
+	"math/big"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	tester "github.com/dreamer-zq/evm-tester"
+	"github.com/dreamer-zq/evm-tester/simple/gen"
+)
+
+var _ Contract = &ERC20Sampler{}
+
+// randomAddress is the recipient param sending every transaction to a new random address.
+const randomAddress = "random"
+
+// ERC20Sampler is a struct that implements the Contract interface for the ERC20Token contract.
+type ERC20Sampler struct {
+	contractAddr common.Address
+}
+
+// SetContractAddr sets the contract address for the ERC20Sampler.
+func (es *ERC20Sampler) SetContractAddr(contractAddr common.Address) {
+	es.contractAddr = contractAddr
+}
+
+// GenTxBuilder generates a CreateTx function calling method with params.
+//
+// It takes the client, the method name and its string params as parameters.
+// It returns a CreateTx function and an error.
+func (es *ERC20Sampler) GenTxBuilder(conn *ethclient.Client, method string, params []string) (tester.CreateTx, error) {
+	methodMap, err := es.MethodMap(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := methodMap[method]
+	if !ok {
+		return nil, errors.New("invalid method")
+	}
+	p, err := m.FormatParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return m.GenTx(opts, p...)
+	}, nil
+}
+
+// Deploy deploys the ERC20Token contract, the whole supply is minted to the deployer.
+//
+// It takes an authenticated transaction options, a contract backend and the
+// `name,symbol,supply` params as parameters, supply is in the smallest unit of the token.
+// It returns the address of the deployed contract and an error if the deployment fails.
+func (es *ERC20Sampler) Deploy(auth *bind.TransactOpts, backend bind.ContractBackend, params []string) (common.Address, error) {
+	if len(params) != 3 {
+		return common.Address{}, errors.New("invalid contract params, expected name,symbol,supply")
+	}
+	supply, err := parseAmount(params[2])
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	contractAddr, _, _, err := gen.DeployERC20Token(auth, backend, params[0], params[1], supply)
+	if err != nil {
+		return common.Address{}, errors.Wrap(err, "failed to deploy contract")
+	}
+	return contractAddr, nil
+}
+
+// MethodMap returns a map of methods for the ERC20Sampler type.
+func (es *ERC20Sampler) MethodMap(conn *ethclient.Client) (map[string]Method, error) {
+	token, err := gen.NewERC20Token(es.contractAddr, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	abi, err := gen.ERC20TokenMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]Method{
+		"transfer":     ERC20SamplerTransferMethod{token, abi},
+		"approve":      ERC20SamplerApproveMethod{token, abi},
+		"transferFrom": ERC20SamplerTransferFromMethod{token, abi},
+	}, nil
+}
+
+// DistributeTxBuilder generates a CreateTx function sending amount tokens to
+// every recipient, then approving allowance to every recipient when it is positive.
+//
+// Parameters:
+// - backend: the backend of the token.
+// - recipients: the accounts receiving the tokens.
+// - amount: the tokens sent to every account.
+// - allowance: the tokens every account may spend from the sender with transferFrom, nil or zero approves nothing.
+//
+// Returns:
+// - tester.CreateTx: returns tester.ErrExit once every transaction has been built.
+// - error: An error if the token can not be bound.
+func (es *ERC20Sampler) DistributeTxBuilder(backend bind.ContractBackend, recipients []common.Address, amount, allowance *big.Int) (tester.CreateTx, error) {
+	token, err := gen.NewERC20Token(es.contractAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	total := int64(len(recipients))
+	if allowance != nil && allowance.Sign() > 0 {
+		total *= 2
+	}
+	var next atomic.Int64
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		n := next.Add(1) - 1
+		switch {
+		case n >= total:
+			return nil, tester.ErrExit
+		case n < int64(len(recipients)):
+			return token.Transfer(opts, recipients[n], amount)
+		default:
+			return token.Approve(opts, recipients[n-int64(len(recipients))], allowance)
+		}
+	}, nil
+}
+
+// ERC20SamplerTransferMethod is a struct that implements the Method interface.
+type ERC20SamplerTransferMethod struct {
+	contract *gen.ERC20Token
+	abi      *abi.ABI
+}
+
+// FormatParams formats the `to,amount` params, to is an address, a JSON array
+// of addresses used in turn or `random` for a new address every transfer.
+func (t ERC20SamplerTransferMethod) FormatParams(params []string) ([]interface{}, error) {
+	if len(params) != 2 {
+		return nil, errors.New("invalid contract params, expected to,amount")
+	}
+	to, err := parseRecipient(params[0], params[1])
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{to}, nil
+}
+
+// GenTx generates a transaction transferring tokens to the next recipient.
+func (t ERC20SamplerTransferMethod) GenTx(opts *bind.TransactOpts, params ...interface{}) (*types.Transaction, error) {
+	if len(params) != 1 {
+		return nil, errors.New("invalid contract params")
+	}
+	to, amount, err := params[0].(tester.Recipient)(opts)
+	if err != nil {
+		return nil, err
+	}
+	return t.contract.Transfer(opts, to, amount)
+}
+
+// Display returns the string representation of the ERC20SamplerTransferMethod.
+func (t ERC20SamplerTransferMethod) Display() string {
+	return t.abi.Methods["transfer"].String()
+}
+
+// ERC20SamplerApproveMethod is a struct that implements the Method interface.
+type ERC20SamplerApproveMethod struct {
+	contract *gen.ERC20Token
+	abi      *abi.ABI
+}
+
+// FormatParams formats the `spender,amount` params, spender is an address, a
+// JSON array of addresses used in turn or `random` for a new address every approval.
+func (t ERC20SamplerApproveMethod) FormatParams(params []string) ([]interface{}, error) {
+	if len(params) != 2 {
+		return nil, errors.New("invalid contract params, expected spender,amount")
+	}
+	spender, err := parseRecipient(params[0], params[1])
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{spender}, nil
+}
+
+// GenTx generates a transaction approving the next spender.
+func (t ERC20SamplerApproveMethod) GenTx(opts *bind.TransactOpts, params ...interface{}) (*types.Transaction, error) {
+	if len(params) != 1 {
+		return nil, errors.New("invalid contract params")
+	}
+	spender, amount, err := params[0].(tester.Recipient)(opts)
+	if err != nil {
+		return nil, err
+	}
+	return t.contract.Approve(opts, spender, amount)
+}
+
+// Display returns the string representation of the ERC20SamplerApproveMethod.
+func (t ERC20SamplerApproveMethod) Display() string {
+	return t.abi.Methods["approve"].String()
+}
+
+// ERC20SamplerTransferFromMethod is a struct that implements the Method interface.
+type ERC20SamplerTransferFromMethod struct {
+	contract *gen.ERC20Token
+	abi      *abi.ABI
+}
+
+// FormatParams formats the `from,to,amount` params, from must have approved
+// the senders, to is an address, a JSON array of addresses used in turn or
+// `random` for a new address every transfer.
+func (t ERC20SamplerTransferFromMethod) FormatParams(params []string) ([]interface{}, error) {
+	if len(params) != 3 {
+		return nil, errors.New("invalid contract params, expected from,to,amount")
+	}
+	if !common.IsHexAddress(params[0]) {
+		return nil, errors.Errorf("invalid address: %s", params[0])
+	}
+	to, err := parseRecipient(params[1], params[2])
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{common.HexToAddress(params[0]), to}, nil
+}
+
+// GenTx generates a transaction transferring the tokens of the owner to the next recipient.
+func (t ERC20SamplerTransferFromMethod) GenTx(opts *bind.TransactOpts, params ...interface{}) (*types.Transaction, error) {
+	if len(params) != 2 {
+		return nil, errors.New("invalid contract params")
+	}
+	from := params[0].(common.Address)
+	to, amount, err := params[1].(tester.Recipient)(opts)
+	if err != nil {
+		return nil, err
+	}
+	return t.contract.TransferFrom(opts, from, to, amount)
+}
+
+// Display returns the string representation of the ERC20SamplerTransferFromMethod.
+func (t ERC20SamplerTransferFromMethod) Display() string {
+	return t.abi.Methods["transferFrom"].String()
+}
+
+// parseRecipient parses the recipients of the same amount, an address, a JSON
+// array of addresses used in turn or `random`.
+func parseRecipient(param, amountStr string) (tester.Recipient, error) {
+	amount, err := parseAmount(amountStr)
+	if err != nil {
+		return nil, err
+	}
+
+	param = strings.TrimSpace(param)
+	switch {
+	case param == randomAddress:
+		return tester.ToRandom(amount), nil
+	case strings.HasPrefix(param, "["):
+		addrs, err := splitJSON(param)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, errors.New("no recipient")
+		}
+		recipients := make([]common.Address, 0, len(addrs))
+		for _, addr := range addrs {
+			if !common.IsHexAddress(addr) {
+				return nil, errors.Errorf("invalid address: %s", addr)
+			}
+			recipients = append(recipients, common.HexToAddress(addr))
+		}
+		return tester.CycleRecipients(recipients, amount), nil
+	case common.IsHexAddress(param):
+		return tester.ToAddress(common.HexToAddress(param), amount), nil
+	default:
+		return nil, errors.Errorf("invalid address: %s", param)
+	}
+}
+
+// parseAmount parses a token amount in the smallest unit of the token.
+func parseAmount(amountStr string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(strings.TrimSpace(amountStr), 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, errors.Errorf("invalid amount: %s", amountStr)
+	}
+	return amount, nil
+}
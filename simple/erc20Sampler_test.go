@@ -0,0 +1,170 @@
+package simple
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	tester "github.com/dreamer-zq/evm-tester"
+	"github.com/dreamer-zq/evm-tester/simple/gen"
+)
+
+func TestParseRecipient(t *testing.T) {
+	a := common.HexToAddress("0x5FbDB2315678afecb367f032d93F642f64180aa3")
+	b := common.HexToAddress("0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512")
+	opts := &bind.TransactOpts{}
+
+	next, err := parseRecipient(a.Hex(), "7")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		to, amount, err := next(opts)
+		require.NoError(t, err)
+		require.Equal(t, a, to)
+		require.Equal(t, big.NewInt(7), amount)
+	}
+
+	next, err = parseRecipient(`["`+a.Hex()+`", "`+b.Hex()+`"]`, "7")
+	require.NoError(t, err)
+	for _, want := range []common.Address{a, b, a} {
+		to, _, err := next(opts)
+		require.NoError(t, err)
+		require.Equal(t, want, to)
+	}
+
+	next, err = parseRecipient("random", "7")
+	require.NoError(t, err)
+	first, _, err := next(opts)
+	require.NoError(t, err)
+	second, amount, err := next(opts)
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+	require.Equal(t, big.NewInt(7), amount)
+
+	for _, param := range [][2]string{{"0x1234", "7"}, {"[]", "7"}, {a.Hex(), "-1"}, {a.Hex(), "seven"}} {
+		_, err := parseRecipient(param[0], param[1])
+		require.Error(t, err, param)
+	}
+}
+
+func TestERC20Sampler(t *testing.T) {
+	holderKey, _ := crypto.GenerateKey()
+	spenderKey, _ := crypto.GenerateKey()
+	holder := crypto.PubkeyToAddress(holderKey.PublicKey)
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	ether := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		holder:  {Balance: ether},
+		spender: {Balance: ether},
+	}, 30_000_000)
+	defer backend.Close()
+
+	holderOpts, err := bind.NewKeyedTransactorWithChainID(holderKey, big.NewInt(1337))
+	require.NoError(t, err)
+	spenderOpts, err := bind.NewKeyedTransactorWithChainID(spenderKey, big.NewInt(1337))
+	require.NoError(t, err)
+
+	sampler := &ERC20Sampler{}
+	_, err = sampler.Deploy(holderOpts, backend, []string{"Token", "TKN"})
+	require.Error(t, err)
+	contractAddr, err := sampler.Deploy(holderOpts, backend, []string{"Token", "TKN", "1000"})
+	require.NoError(t, err)
+	backend.Commit()
+	sampler.SetContractAddr(contractAddr)
+
+	token, err := gen.NewERC20Token(contractAddr, backend)
+	require.NoError(t, err)
+	name, err := token.Name(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, "Token", name)
+	symbol, err := token.Symbol(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, "TKN", symbol)
+	requireBalance(t, token, holder, 1000)
+
+	// distribute 100 tokens to 3 accounts and approve 10 tokens to each of them
+	recipients := []common.Address{spender, common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+	createTx, err := sampler.DistributeTxBuilder(backend, recipients, big.NewInt(100), big.NewInt(10))
+	require.NoError(t, err)
+	for i := 0; i < 2*len(recipients); i++ {
+		_, err := createTx(holderOpts)
+		require.NoError(t, err)
+		backend.Commit()
+	}
+	_, err = createTx(holderOpts)
+	require.ErrorIs(t, err, tester.ErrExit)
+	requireBalance(t, token, holder, 700)
+	for _, recipient := range recipients {
+		requireBalance(t, token, recipient, 100)
+		allowance, err := token.Allowance(&bind.CallOpts{}, holder, recipient)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(10), allowance)
+	}
+
+	createTx, err = sampler.DistributeTxBuilder(backend, recipients, big.NewInt(1), big.NewInt(0))
+	require.NoError(t, err)
+	for range recipients {
+		_, err := createTx(holderOpts)
+		require.NoError(t, err)
+	}
+	_, err = createTx(holderOpts)
+	require.ErrorIs(t, err, tester.ErrExit)
+	backend.Commit()
+
+	abi, err := gen.ERC20TokenMetaData.GetAbi()
+	require.NoError(t, err)
+	other := common.HexToAddress("0x03")
+
+	transfer := ERC20SamplerTransferMethod{token, abi}
+	params, err := transfer.FormatParams([]string{other.Hex(), "5"})
+	require.NoError(t, err)
+	_, err = transfer.GenTx(spenderOpts, params...)
+	require.NoError(t, err)
+	backend.Commit()
+	requireBalance(t, token, other, 5)
+
+	approve := ERC20SamplerApproveMethod{token, abi}
+	params, err = approve.FormatParams([]string{other.Hex(), "3"})
+	require.NoError(t, err)
+	_, err = approve.GenTx(spenderOpts, params...)
+	require.NoError(t, err)
+	backend.Commit()
+	allowance, err := token.Allowance(&bind.CallOpts{}, spender, other)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3), allowance)
+
+	transferFrom := ERC20SamplerTransferFromMethod{token, abi}
+	params, err = transferFrom.FormatParams([]string{holder.Hex(), other.Hex(), "4"})
+	require.NoError(t, err)
+	_, err = transferFrom.GenTx(spenderOpts, params...)
+	require.NoError(t, err)
+	backend.Commit()
+	requireBalance(t, token, other, 9)
+	allowance, err = token.Allowance(&bind.CallOpts{}, holder, spender)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(6), allowance)
+
+	// the remaining allowance is exceeded
+	spenderOpts.GasLimit = 100_000
+	params, err = transferFrom.FormatParams([]string{holder.Hex(), other.Hex(), "7"})
+	require.NoError(t, err)
+	tx, err := transferFrom.GenTx(spenderOpts, params...)
+	require.NoError(t, err)
+	backend.Commit()
+	receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Zero(t, receipt.Status)
+}
+
+func requireBalance(t *testing.T, token *gen.ERC20Token, account common.Address, want int64) {
+	balance, err := token.BalanceOf(&bind.CallOpts{}, account)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(want), balance, account.Hex())
+}
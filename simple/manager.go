@@ -2,17 +2,18 @@ package simple
 
 // Manager is a struct that manages a map of samplers.
 type Manager struct {
-	ms map[string]Contract
+	ms map[string]func() Contract
 }
 
 // NewManager creates a new Manager.
 //
 // It returns a pointer to a Manager struct.
 func NewManager() *Manager {
-	ms := make(map[string]Contract)
-	ms["eTicket"] = &ETicketSampler{}
-	ms["ticket"] = &TicketGameSampler{}
-	ms["poap"] = &POAPSampler{}
+	ms := make(map[string]func() Contract)
+	ms["eTicket"] = func() Contract { return &ETicketSampler{} }
+	ms["ticket"] = func() Contract { return &TicketGameSampler{} }
+	ms["poap"] = func() Contract { return &POAPSampler{} }
+	ms["erc20"] = func() Contract { return &ERC20Sampler{} }
 	return &Manager{
 		ms: ms,
 	}
@@ -29,14 +30,14 @@ func (m *Manager) ListContracts() (names []string) {
 }
 
 
-// GetContract returns the Contract with the given name.
+// GetContract returns a new Contract with the given name.
 //
 // It takes a string parameter called name which is the name of the Contract to retrieve.
-// It returns a Contract.
+// It returns a Contract, a new instance on every call.
 func (m *Manager) GetContract(name string) Contract {
-	sampler, ok := m.ms[name]
+	newSampler, ok := m.ms[name]
 	if !ok {
 		panic("invalid sampler name")
 	}
-	return sampler
+	return newSampler()
 }
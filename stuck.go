@@ -0,0 +1,155 @@
+package tester
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StuckOff represents leaving the stuck transactions in the mempool.
+	StuckOff StuckPolicy = "off"
+	// StuckSpeedUp represents replacing a stuck transaction by the same one with higher fees.
+	StuckSpeedUp StuckPolicy = "speedup"
+	// StuckCancel represents replacing a stuck transaction by a zero-value transfer to its sender.
+	StuckCancel StuckPolicy = "cancel"
+)
+
+// StuckPolicy represents what happens to the transactions that stay too long in the mempool.
+type StuckPolicy string
+
+// ParseStuckPolicy parses the input string and returns the corresponding StuckPolicy.
+//
+// Parameters:
+// - policy: The input string to be parsed.
+//
+// Return types:
+// - StuckPolicy: The corresponding StuckPolicy constant.
+// - error: An error if the input string does not match any of the defined policies.
+func ParseStuckPolicy(policy string) (StuckPolicy, error) {
+	switch policy {
+	case string(StuckOff):
+		return StuckOff, nil
+	case string(StuckSpeedUp):
+		return StuckSpeedUp, nil
+	case string(StuckCancel):
+		return StuckCancel, nil
+	default:
+		return "", fmt.Errorf("invalid stuck policy: %s", policy)
+	}
+}
+
+// blobPriceBump is the minimum fee increase in percent of a blob transaction
+// replacement, the blob pool of the nodes requires the fees to be doubled.
+const blobPriceBump = 100
+
+// replacer replaces the transactions stuck in the mempool with the same nonce
+// and higher fees.
+type replacer struct {
+	policy StuckPolicy
+	after  time.Duration
+	bump   int64
+	eth    *ethclient.Client
+	key    func(addr common.Address) *ecdsa.PrivateKey
+
+	replaced atomic.Int64
+	failed   atomic.Int64
+}
+
+func newReplacer(
+	policy StuckPolicy,
+	after time.Duration,
+	bump int64,
+	eth *ethclient.Client,
+	key func(addr common.Address) *ecdsa.PrivateKey,
+) *replacer {
+	return &replacer{
+		policy: policy,
+		after:  after,
+		bump:   bump,
+		eth:    eth,
+		key:    key,
+	}
+}
+
+// stuck returns whether the last transaction of ele has waited long enough to be replaced.
+func (r *replacer) stuck(ele *element, now time.Time) bool {
+	return r != nil && ele.tx != nil && now.Sub(ele.lastSentAt) >= r.after
+}
+
+// owns returns whether the policy replaces the transactions of ele, it then
+// decides how long they wait instead of the verify mode.
+func (r *replacer) owns(ele *element) bool {
+	return r != nil && ele.tx != nil
+}
+
+// replace sends the replacement of the last transaction of ele, and returns it.
+func (r *replacer) replace(ctx context.Context, ele *element) (*types.Transaction, error) {
+	key := r.key(ele.from)
+	if key == nil {
+		return nil, errors.Errorf("no key to replace the transactions of %s", ele.from)
+	}
+
+	head, err := r.eth.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch the latest header")
+	}
+
+	bump := r.bump
+	if ele.tx.Type() == types.BlobTxType {
+		bump = max(bump, blobPriceBump)
+	}
+	tx, err := signTx(ele.tx, key, func(f *txFields) {
+		f.gasTipCap = bumpFee(f.gasTipCap, bump)
+		f.gasFeeCap = bumpFee(f.gasFeeCap, bump)
+		if f.blobFeeCap != nil {
+			f.blobFeeCap = bumpFee(f.blobFeeCap, bump)
+		}
+		// follow a base fee spike, as bind.TransactOpts does
+		if head.BaseFee != nil {
+			if minFeeCap := new(big.Int).Add(f.gasTipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2))); f.gasFeeCap.Cmp(minFeeCap) < 0 {
+				f.gasFeeCap = minFeeCap
+			}
+		}
+		// a blob transaction can only be replaced by another one, the cancel keeps its blobs
+		if r.policy == StuckCancel {
+			f.to = &ele.from
+			f.value = new(big.Int)
+			f.data = nil
+			f.gas = params.TxGas
+			f.accessList = nil
+		}
+	})
+	if err != nil {
+		r.failed.Add(1)
+		return nil, err
+	}
+	if err := r.eth.SendTransaction(ctx, tx); err != nil {
+		r.failed.Add(1)
+		return nil, err
+	}
+	r.replaced.Add(1)
+	slog.Info("replace stuck transaction", "policy", r.policy, "hash", ele.tx.Hash(), "replacement", tx.Hash(), "nonce", tx.Nonce())
+	return tx, nil
+}
+
+// bumpFee raises fee by percent, rounded up so that the node accepts the replacement.
+func bumpFee(fee *big.Int, percent int64) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
+}
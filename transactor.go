@@ -2,11 +2,14 @@ package tester
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -14,6 +17,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/olekukonko/tablewriter"
@@ -28,6 +33,8 @@ const (
 	Segment SendMode = "segment"
 	// Batch represents sending transactions in batches.
 	Batch SendMode = "batch"
+	// Rate represents sending transactions at a constant target rate.
+	Rate SendMode = "rate"
 )
 
 // SendMode represents the mode of sending transactions.
@@ -53,6 +60,8 @@ func ParseSendMode(mode string) (SendMode, error) {
 		return Segment, nil
 	case string(Batch):
 		return Batch, nil
+	case string(Rate):
+		return Rate, nil
 	default:
 		return "", fmt.Errorf("invalid send mode: %s", mode)
 	}
@@ -65,8 +74,20 @@ type Result struct {
 	TotalTxCount       atomic.Int64
 	StartTime          time.Time
 	EndTime            time.Time
-	MinResponseTime    int64
-	MaxResponseTime    int64
+	ResponseTime       *Histogram
+	Errors             map[ErrorClass]int64
+	ErrorSamples       map[ErrorClass][]string
+}
+
+// maxErrorSamples is the number of distinct messages kept for every error class.
+const maxErrorSamples = 3
+
+func newResult() *Result {
+	return &Result{
+		ResponseTime: NewHistogram(),
+		Errors:       make(map[ErrorClass]int64),
+		ErrorSamples: make(map[ErrorClass][]string),
+	}
 }
 
 // BatchResult represents the result of a batch of transactions.
@@ -76,10 +97,15 @@ type BatchResult struct {
 }
 
 type tallyItem struct {
-	txHash  common.Hash
-	batchNo int64
-	err     error
-	took    int64
+	tx       *types.Transaction
+	from     common.Address
+	batchNo  int64
+	stage    int
+	endpoint string
+	entry    string
+	err      error
+	sentAt   time.Time
+	took     int64
 }
 
 // TransactorOpts is a function that takes in a pointer to a Transactor object
@@ -121,24 +147,189 @@ func SetSendMode(sendMode SendMode) TransactorOpts {
 	}
 }
 
+// SetTargetRate sets the target number of transactions per second for the
+// rate send mode.
+//
+// Parameters:
+// - rate: the number of transactions to send per second.
+//
+// Returns:
+// - a function that sets the target rate and returns the Transactor.
+func SetTargetRate(rate float64) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		t.targetRate = rate
+		return t
+	}
+}
+
+// SetLoadProfile sets the load profile the Transactor follows.
+//
+// Parameters:
+// - profile: the stages to run, in order.
+//
+// Returns:
+// - a function that sets the load profile and returns the Transactor.
+func SetLoadProfile(profile LoadProfile) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		t.profile = profile
+		return t
+	}
+}
+
+// SetTimeSeriesOutput sets the path of the per-second time series written during the run.
+//
+// Parameters:
+// - path: the CSV or JSON lines (`.jsonl`) file path, empty disables the time series.
+//
+// Returns:
+// - a function that sets the time series path and returns the Transactor.
+func SetTimeSeriesOutput(path string) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		t.timeSeriesPath = path
+		return t
+	}
+}
+
+// SetMetrics sets the Prometheus metrics endpoint.
+//
+// Parameters:
+// - metrics: the metrics created by NewMetrics, nil disables the endpoint.
+//
+// Returns:
+// - a function that sets the metrics and returns the Transactor.
+func SetMetrics(metrics *Metrics) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		t.metrics = metrics
+		return t
+	}
+}
+
+// SetWatchBlocks sets whether the Transactor follows the blocks produced during the run.
+//
+// Parameters:
+// - enable: whether to measure the on-chain throughput.
+//
+// Returns:
+// - a function that enables the block watcher and returns the Transactor.
+func SetWatchBlocks(enable bool) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		if enable {
+			t.watcher = NewBlockWatcher(t.eth)
+		}
+		return t
+	}
+}
+
+// SetVerifyMode sets the way the Verifier finds the receipts of the transactions.
+//
+// Parameters:
+// - mode: the verify mode to be set.
+//
+// Returns:
+// - a function that sets the verify mode and returns the Transactor.
+func SetVerifyMode(mode VerifyMode) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		t.verifyMode = mode
+		return t
+	}
+}
+
+// SetEndpoints sets the endpoints the transactions are spread over.
+//
+// Parameters:
+// - endpoints: the endpoint selector, nil sends everything to the client of the Transactor.
+//
+// Returns:
+// - a function that sets the endpoints and returns the Transactor.
+func SetEndpoints(endpoints *EndpointSelector) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		t.endpoints = endpoints
+		return t
+	}
+}
+
+// SetNonceResync sets whether the Transactor resyncs the nonces of the senders.
+//
+// When a send fails, the following transactions of the sender are re-signed to
+// fill the nonce gap, and a transaction rejected for its nonce is re-signed with
+// the pending nonce of the sender and sent again. The nonces of the generator
+// follow the resyncs. The transactions of a sender are then sent one by one,
+// except in the `batch` and `rate` send modes which are not resynced.
+//
+// Parameters:
+// - enable: whether to resync the nonces.
+//
+// Returns:
+// - a function that enables the nonce resync and returns the Transactor.
+func SetNonceResync(enable bool) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		if enable {
+			t.nonces = newNonceResync(t.eth, t.senderKey, t.gen.shiftNonce)
+		}
+		return t
+	}
+}
+
+// SetStuckPolicy sets what the Verifier does with the transactions that stay too long in the mempool.
+//
+// A stuck transaction is replaced by one with the same nonce and fees bumped
+// by bump percent, either the same call (speed-up) or a zero-value transfer to
+// its sender (cancel). It requires the verification.
+//
+// Parameters:
+// - policy: the stuck policy, StuckOff keeps the transactions as they are.
+// - after: the time a transaction waits before it is replaced.
+// - bump: the fee increase of a replacement, in percent.
+//
+// Returns:
+// - a function that sets the stuck policy and returns the Transactor.
+func SetStuckPolicy(policy StuckPolicy, after time.Duration, bump int64) TransactorOpts {
+	return func(t *Transactor) *Transactor {
+		if policy != "" && policy != StuckOff {
+			t.stuck = newReplacer(policy, after, bump, t.eth, t.senderKey)
+		}
+		return t
+	}
+}
+
 // Transactor is a struct that can be used to send transactions.
 type Transactor struct {
-	eth  *ethclient.Client
-	pool *Pool
+	eth       *ethclient.Client
+	endpoints *EndpointSelector
+	pool      *Pool
 
 	totalBatch int64
 	batchNo    atomic.Int64
 	produceTxs atomic.Int64
+	sentTxs    atomic.Int64
 	endTime    time.Time
 	gen        *TxGenerator
 	batch      chan *BatchResult
 	tallyCh    chan *tallyItem
 	mu         sync.Mutex
 	verifer    *Verifier
+	verifyMode VerifyMode
+
+	sendMode   SendMode
+	targetRate float64
+	limiter    *limiter
+	rs         *Result
+	segments   map[int64]*Result
 
-	sendMode SendMode
-	rs       *Result
-	segments map[int64]*Result
+	profile LoadProfile
+	stage   atomic.Int32
+	stages  map[int]*Result
+
+	endpointResults map[string]*Result
+	entryResults    map[string]*Result
+	lanes           map[common.Address]chan struct{}
+	nonces          *nonceResync
+	stuck           *replacer
+
+	timeSeriesPath string
+	reporter       *Reporter
+	metrics        *Metrics
+	watcher        *BlockWatcher
 
 	producerExit atomic.Bool
 	consumerExit atomic.Bool
@@ -153,18 +344,46 @@ type Transactor struct {
 func NewTransactor(eth *ethclient.Client, maxConcurrentNum int, gen *TxGenerator, enable bool, opts ...TransactorOpts) *Transactor {
 	transactor := &Transactor{
 		eth:      eth,
-		pool:     NewPool(maxConcurrentNum, "transactor"),
-		rs:       &Result{},
+		rs:       newResult(),
 		gen:      gen,
-		batch:    make(chan *BatchResult, 1000),
 		tallyCh:  make(chan *tallyItem, 5000),
 		exit:     make(chan int),
 		segments: make(map[int64]*Result),
+		stages:   make(map[int]*Result),
+
+		endpointResults: make(map[string]*Result),
+		entryResults:    make(map[string]*Result),
+		lanes:           make(map[common.Address]chan struct{}),
 	}
 	for _, opt := range opts {
 		transactor = opt(transactor)
 	}
-	transactor.verifer = NewVerifier(enable, transactor.eth)
+	if transactor.endpoints == nil {
+		transactor.endpoints = NewEndpointSelector([]*Endpoint{{URL: "default", Client: eth}}, RoundRobin)
+	}
+	if len(transactor.profile) > 0 && maxConcurrentNum <= 0 {
+		// an unbounded pool can not be tuned, start from the first stage instead,
+		// or from the largest stage when the first one leaves the users as they are
+		if _, users, _ := transactor.profile.at(0); users > 0 {
+			maxConcurrentNum = users
+		} else {
+			maxConcurrentNum = transactor.profile.maxUsers()
+		}
+	}
+	transactor.pool = NewPool(maxConcurrentNum, "transactor")
+	if transactor.sendMode == Rate {
+		// keep the producer just ahead of the pacer, otherwise a long backlog of
+		// batches is still sent after the run period is over
+		transactor.batch = make(chan *BatchResult, 1)
+		transactor.limiter = newLimiter(transactor.targetRate)
+	} else {
+		transactor.batch = make(chan *BatchResult, 1000)
+	}
+	transactor.verifer = NewVerifier(enable, transactor.eth, transactor.verifyMode)
+	transactor.verifer.stuck = transactor.stuck
+	transactor.verifer.mix = transactor.gen.mix
+	transactor.metrics.watchPools(transactor.pool, transactor.verifer.queue.p)
+	transactor.verifer.metrics = transactor.metrics
 	return transactor
 }
 
@@ -175,6 +394,22 @@ func NewTransactor(eth *ethclient.Client, maxConcurrentNum int, gen *TxGenerator
 // it prints statistics about the transaction processing, closes the transaction pool,
 // and returns.
 func (t *Transactor) Run() {
+	if len(t.profile) > 0 {
+		t.endTime = time.Now().Add(t.profile.Duration())
+		t.applyStage(0)
+		go t.followProfile()
+	}
+	if t.timeSeriesPath != "" {
+		reporter, err := NewReporter(t.timeSeriesPath, t.snapshot)
+		if err != nil {
+			slog.Error("failed to create time series", "path", t.timeSeriesPath, "err", err)
+		} else {
+			t.reporter = reporter
+			go t.reporter.Start()
+		}
+	}
+	go t.metrics.Serve()
+	go t.watcher.Start()
 	go t.listenExit()
 	go t.produceTx()
 	go t.startTally()
@@ -208,6 +443,11 @@ func (t *Transactor) Stop() {
 // No return type.
 func (t *Transactor) Exit() {
 	t.pool.Close()
+	if t.reporter != nil {
+		t.reporter.Stop()
+	}
+	t.metrics.Close()
+	t.watcher.Stop()
 	t.printResult()
 	close(t.batch)
 	close(t.tallyCh)
@@ -255,10 +495,36 @@ func (t *Transactor) consumeTx() {
 
 func (t *Transactor) startTally() {
 	for item := range t.tallyCh {
-		if item.err == nil {
-			t.verifer.Add(item.txHash)
+		t.tally(item)
+	}
+}
+
+func (t *Transactor) followProfile() {
+	begin := time.Now()
+	tick := time.NewTicker(100 * time.Millisecond)
+	defer tick.Stop()
+
+	for now := range tick.C {
+		elapsed := now.Sub(begin)
+		if elapsed >= t.profile.Duration() || t.producerExit.Load() {
+			return
 		}
-		t.tally(item.batchNo, item.err, item.took)
+		t.applyStage(elapsed)
+	}
+}
+
+// applyStage tunes the pool and the pacer to the load the profile asks for at elapsed.
+func (t *Transactor) applyStage(elapsed time.Duration) {
+	idx, users, rate := t.profile.at(elapsed)
+	if int(t.stage.Swap(int32(idx))) != idx {
+		stage := t.profile[idx]
+		slog.Info("enter load stage", "name", stage.Name, "duration", stage.Duration, "users", stage.Users, "rate", stage.Rate, "shape", stage.Shape)
+	}
+	if users > 0 {
+		t.pool.Tune(users)
+	}
+	if rate > 0 && t.limiter != nil {
+		t.limiter.SetRate(rate)
 	}
 }
 
@@ -292,7 +558,7 @@ func (t *Transactor) stopProducer() bool {
 	}
 
 	// endTime is a counter that keeps track of the total number of transactions sent
-	if !t.endTime.IsZero() && now.Second() >= t.endTime.Second() {
+	if !t.endTime.IsZero() && !now.Before(t.endTime) {
 		t.producerExit.Store(true)
 		return true
 	}
@@ -308,9 +574,14 @@ func (t *Transactor) stopConsumer() {
 }
 
 func (t *Transactor) stopVerifier() {
+	// only the transactions accepted by the node are handed over to the verifier
+	t.mu.Lock()
+	acked := t.rs.TotalTxCount.Load() - t.rs.TotalFailedTxCount
+	t.mu.Unlock()
+
 	if t.producerExit.Load() &&
 		t.consumerExit.Load() &&
-		t.verifer.Finish(t.rs.TotalTxCount.Load()) {
+		t.verifer.Finish(acked) {
 		t.Stop()
 	}
 }
@@ -338,43 +609,123 @@ func (t *Transactor) sendTx(ctx context.Context, batch *BatchResult) {
 	case Batch:
 		t.sendTxsBatch(ctx, batch)
 		break
+	case Rate:
+		t.sendTxsRate(ctx, batch)
+		break
 	}
 }
 
 func (t *Transactor) sendTxsParallel(ctx context.Context, batch *BatchResult) {
+	if t.gen.senders != nil || t.nonces != nil {
+		t.sendTxsBySender(ctx, batch)
+		return
+	}
 	for _, payload := range batch.payloads {
-		txCopy := *payload.Tx
+		payload := payload
 		t.pool.Submit(func() {
-			begin := time.Now()
-			err := t.eth.SendTransaction(ctx, &txCopy)
-			t.tallyCh <- &tallyItem{txCopy.Hash(), batch.batchNo, err, time.Since(begin).Nanoseconds()}
+			t.send(ctx, batch.batchNo, payload)
 		})
 	}
 }
 
-func (t *Transactor) sendTxsSegment(ctx context.Context, batch *BatchResult) {
+// sendTxsBySender sends the transactions of different senders in parallel,
+// and the transactions of the same sender one by one in nonce order.
+func (t *Transactor) sendTxsBySender(ctx context.Context, batch *BatchResult) {
+	var senders []common.Address
+	lanes := make(map[common.Address][]*Payload)
+	for _, payload := range batch.payloads {
+		if _, ok := lanes[payload.From]; !ok {
+			senders = append(senders, payload.From)
+		}
+		lanes[payload.From] = append(lanes[payload.From], payload)
+	}
+	for _, sender := range senders {
+		lane := lanes[sender]
+		t.submitToLane(sender, func() {
+			t.sendLane(ctx, batch.batchNo, lane)
+		})
+	}
+}
+
+// submitToLane submits f to the pool once the previous function submitted for
+// the sender is done.
+func (t *Transactor) submitToLane(sender common.Address, f func()) {
+	// the lane of the previous batch of the sender may still be running,
+	// chain them so that the nonces are never sent out of order
+	prev, done := t.lanes[sender], make(chan struct{})
+	t.lanes[sender] = done
+	t.pool.Submit(func() {
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		f()
+	})
+}
+
+func (t *Transactor) sendTxsRate(ctx context.Context, batch *BatchResult) {
 	for _, payload := range batch.payloads {
-		txCopy := *payload.Tx
+		payload := payload
+		t.limiter.Wait()
+		if t.gen.senders != nil {
+			// paced as the others, but sent after the previous transaction of the sender
+			t.submitToLane(payload.From, func() {
+				t.send(ctx, batch.batchNo, payload)
+			})
+			continue
+		}
 		t.pool.Submit(func() {
-			begin := time.Now()
-			err := t.eth.SendTransaction(ctx, &txCopy)
-			t.tallyCh <- &tallyItem{txCopy.Hash(), batch.batchNo, err, time.Since(begin).Nanoseconds()}
+			t.send(ctx, batch.batchNo, payload)
 		})
 	}
+}
+
+func (t *Transactor) sendTxsSegment(ctx context.Context, batch *BatchResult) {
+	t.sendTxsParallel(ctx, batch)
 	t.pool.Finish()
 }
 
 func (t *Transactor) sendTxsSync(ctx context.Context, batch *BatchResult) {
-	for _, payload := range batch.payloads {
-		begin := time.Now()
-		err := t.eth.SendTransaction(ctx, payload.Tx)
-		t.tallyCh <- &tallyItem{payload.Tx.Hash(), batch.batchNo, err, time.Since(begin).Nanoseconds()}
+	t.sendLane(ctx, batch.batchNo, batch.payloads)
+}
+
+// sendLane sends the transactions one by one, resyncing the nonces of their
+// senders when it is enabled.
+func (t *Transactor) sendLane(ctx context.Context, batchNo int64, payloads []*Payload) {
+	for _, payload := range payloads {
+		if t.nonces == nil {
+			t.send(ctx, batchNo, payload)
+			continue
+		}
+		// the transaction may be sent once more, it is counted before the first
+		// send so that the tally is never closed while the resend is pending
+		t.produceTxs.Add(1)
+		resent := t.nonces.send(ctx, payload, func(payload *Payload) error {
+			return t.send(ctx, batchNo, payload)
+		})
+		if !resent {
+			t.produceTxs.Add(-1)
+		}
 	}
 }
 
+// senderKey returns the private key of a sender of the generator, or nil if it is unknown.
+func (t *Transactor) senderKey(addr common.Address) *ecdsa.PrivateKey {
+	if t.gen.senders != nil {
+		if sender := t.gen.senders.Get(addr); sender != nil {
+			return sender.Key
+		}
+	}
+	if t.gen.privKey != nil && crypto.PubkeyToAddress(t.gen.privKey.PublicKey) == addr {
+		return t.gen.privKey
+	}
+	return nil
+}
+
 func (t *Transactor) sendTxsBatch(ctx context.Context, batch *BatchResult) {
 	elems := make([]rpc.BatchElem, 0, len(batch.payloads))
 	for _, payload := range batch.payloads {
+		t.watcher.Track(payload.Tx.Hash())
 		data, _ := payload.Tx.MarshalBinary()
 		elems = append(elems, rpc.BatchElem{
 			Method: "eth_sendRawTransaction",
@@ -383,15 +734,34 @@ func (t *Transactor) sendTxsBatch(ctx context.Context, batch *BatchResult) {
 		})
 	}
 	t.pool.Submit(func() {
+		stage := int(t.stage.Load())
+		endpoint := t.endpoints.Pick(batch.payloads[0].From)
+		t.sentTxs.Add(int64(len(elems)))
+		t.metrics.txSent(len(elems))
 		begin := time.Now()
-		err := t.eth.Client().BatchCallContext(ctx, elems)
-		if err != nil {
-			return
+		for _, payload := range batch.payloads {
+			t.verifer.Add(payload.Tx, payload.From, payload.Entry, begin)
 		}
+		batchErr := endpoint.Client.Client().BatchCallContext(ctx, elems)
 		took := time.Since(begin).Nanoseconds()
 		for i, elem := range elems {
-			hash := batch.payloads[i].Tx.Hash()
-			t.tallyCh <- &tallyItem{hash, batch.batchNo, elem.Error, took}
+			// a transport error fails every transaction of the batch
+			err := elem.Error
+			if batchErr != nil {
+				err = batchErr
+			}
+			t.verifer.Sent(batch.payloads[i].Tx.Hash(), err)
+			t.tallyCh <- &tallyItem{
+				tx:       batch.payloads[i].Tx,
+				from:     batch.payloads[i].From,
+				batchNo:  batch.batchNo,
+				stage:    stage,
+				endpoint: endpoint.URL,
+				entry:    batch.payloads[i].Entry,
+				err:      err,
+				sentAt:   begin,
+				took:     took,
+			}
 		}
 	})
 	if !t.gen.concurrent {
@@ -399,27 +769,78 @@ func (t *Transactor) sendTxsBatch(ctx context.Context, batch *BatchResult) {
 	}
 }
 
+// send sends a single transaction and hands the outcome over to the tally.
+func (t *Transactor) send(ctx context.Context, batchNo int64, payload *Payload) error {
+	stage := int(t.stage.Load())
+	endpoint := t.endpoints.Pick(payload.From)
+	t.sentTxs.Add(1)
+	t.metrics.txSent(1)
+	// tracked before the send, the transaction may be included before it returns
+	t.watcher.Track(payload.Tx.Hash())
+	begin := time.Now()
+	t.verifer.Add(payload.Tx, payload.From, payload.Entry, begin)
+	err := endpoint.Client.SendTransaction(ctx, payload.Tx)
+	t.verifer.Sent(payload.Tx.Hash(), err)
+	t.tallyCh <- &tallyItem{
+		tx:       payload.Tx,
+		from:     payload.From,
+		batchNo:  batchNo,
+		stage:    stage,
+		endpoint: endpoint.URL,
+		entry:    payload.Entry,
+		err:      err,
+		sentAt:   begin,
+		took:     time.Since(begin).Nanoseconds(),
+	}
+	return err
+}
+
+// snapshot returns the current state of the run for the time series.
+func (t *Transactor) snapshot() *Sample {
+	t.mu.Lock()
+	tallied, failed := t.rs.TotalTxCount.Load(), t.rs.TotalFailedTxCount
+	t.mu.Unlock()
+
+	stat := t.pool.Stat()
+	return &Sample{
+		Time:          time.Now(),
+		Sent:          t.sentTxs.Load(),
+		Acked:         tallied - failed,
+		Failed:        failed,
+		InFlight:      stat.Running,
+		PoolWaiting:   stat.Waiting,
+		BatchQueue:    len(t.batch),
+		TallyQueue:    len(t.tallyCh),
+		VerifyBacklog: t.verifer.Pending(),
+	}
+}
+
 // tally updates the transaction statistics based on the given error and duration.
 //
 // It takes an error as a parameter to determine if the transaction was successful or not.
 // The function also takes an integer value representing the duration of the transaction.
-func (t *Transactor) tally(batchNo int64, err error, took int64) {
+func (t *Transactor) tally(item *tallyItem) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	batchNo, err, took := item.batchNo, item.err, item.took
+	t.metrics.txDone(err, took)
+	if err != nil {
+		slog.Error("failed to send transaction", "err", err, "class", ClassifyError(err), "batchNo", batchNo)
+	}
+
 	count := func(rs *Result, err error, took int64) {
 		rs.Batch = batchNo
-		if rs.MinResponseTime > took || rs.MinResponseTime == 0 {
-			rs.MinResponseTime = took
-		}
-		if rs.MaxResponseTime < took {
-			rs.MaxResponseTime = took
-		}
+		rs.ResponseTime.Record(took)
 
 		rs.TotalTxCount.Add(1)
 		if err != nil {
-			slog.Error("failed to send transaction", "err", err, "batchNo", batchNo)
 			rs.TotalFailedTxCount++
+			class := ClassifyError(err)
+			rs.Errors[class]++
+			if samples := rs.ErrorSamples[class]; len(samples) < maxErrorSamples && !slices.Contains(samples, err.Error()) {
+				rs.ErrorSamples[class] = append(samples, err.Error())
+			}
 		}
 
 		if rs.StartTime.IsZero() {
@@ -434,15 +855,136 @@ func (t *Transactor) tally(batchNo int64, err error, took int64) {
 	if t.totalBatch > 1 && t.sendMode == Segment {
 		rs, ok := t.segments[batchNo]
 		if !ok {
-			rs = &Result{}
+			rs = newResult()
 			t.segments[batchNo] = rs
 		}
 		count(rs, err, took)
 	}
+	// statistics of the results of each endpoint
+	if len(t.endpoints.Endpoints()) > 1 {
+		rs, ok := t.endpointResults[item.endpoint]
+		if !ok {
+			rs = newResult()
+			t.endpointResults[item.endpoint] = rs
+		}
+		count(rs, err, took)
+	}
+	// statistics of the results of each mix entry
+	if t.gen.mix != nil {
+		rs, ok := t.entryResults[item.entry]
+		if !ok {
+			rs = newResult()
+			t.entryResults[item.entry] = rs
+		}
+		count(rs, err, took)
+	}
+	// statistics of the results of each load stage
+	if len(t.profile) > 0 {
+		rs, ok := t.stages[item.stage]
+		if !ok {
+			rs = newResult()
+			t.stages[item.stage] = rs
+		}
+		count(rs, err, took)
+	}
+}
+
+// mixEntries returns the entries of the mix of the generator, nil without a mix.
+func (t *Transactor) mixEntries() []*MixEntry {
+	if t.gen.mix == nil {
+		return nil
+	}
+	return t.gen.mix.Entries()
+}
+
+// printErrors prints the send errors grouped by class, for the total and for
+// each segment and stage, followed by a few sample messages of every class.
+func (t *Transactor) printErrors() {
+	if t.rs.TotalFailedTxCount == 0 {
+		return
+	}
+
+	fmt.Println("Output error statistics:")
+
+	header := []string{"Scope"}
+	for _, class := range ErrorClasses {
+		header = append(header, string(class))
+	}
+	formatErrors := func(scope string, rs *Result) []string {
+		row := []string{scope}
+		for _, class := range ErrorClasses {
+			row = append(row, strconv.FormatInt(rs.Errors[class], 10))
+		}
+		return row
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetAutoFormatHeaders(false)
+	if t.totalBatch > 1 && t.sendMode == Segment {
+		for batchNo := int64(0); batchNo < t.totalBatch; batchNo++ {
+			if rs, ok := t.segments[batchNo]; ok && rs.TotalFailedTxCount > 0 {
+				table.Append(formatErrors("batch "+strconv.FormatInt(batchNo, 10), rs))
+			}
+		}
+	}
+	for idx, stage := range t.profile {
+		if rs, ok := t.stages[idx]; ok && rs.TotalFailedTxCount > 0 {
+			table.Append(formatErrors("stage "+stage.Name, rs))
+		}
+	}
+	for _, endpoint := range t.endpoints.Endpoints() {
+		if rs, ok := t.endpointResults[endpoint.URL]; ok && rs.TotalFailedTxCount > 0 {
+			table.Append(formatErrors(endpoint.URL, rs))
+		}
+	}
+	for _, entry := range t.mixEntries() {
+		if rs, ok := t.entryResults[entry.Name]; ok && rs.TotalFailedTxCount > 0 {
+			table.Append(formatErrors("entry "+entry.Name, rs))
+		}
+	}
+	table.Append(formatErrors("total", t.rs))
+	table.Render()
+
+	samples := tablewriter.NewWriter(os.Stdout)
+	samples.SetHeader([]string{"Class", "Count", "Samples"})
+	samples.SetAutoFormatHeaders(false)
+	samples.SetAutoWrapText(false)
+	for _, class := range ErrorClasses {
+		if t.rs.Errors[class] == 0 {
+			continue
+		}
+		samples.Append([]string{
+			string(class),
+			strconv.FormatInt(t.rs.Errors[class], 10),
+			strings.Join(t.rs.ErrorSamples[class], "\n"),
+		})
+	}
+	samples.Render()
+}
+
+// latencyHeader is the table header of the columns returned by formatLatency.
+var latencyHeader = []string{"MinResponseTime", "MeanResponseTime", "P50", "P90", "P95", "P99", "P99.9", "MaxResponseTime"}
+
+// formatLatency formats the min, mean, percentiles and max of a latency histogram recorded in nanoseconds.
+func formatLatency(h *Histogram) []string {
+	format := func(v int64) string {
+		return (time.Duration(v) * time.Nanosecond).String()
+	}
+	return []string{
+		format(h.Min()),
+		format(int64(h.Mean())),
+		format(h.ValueAtPercentile(50)),
+		format(h.ValueAtPercentile(90)),
+		format(h.ValueAtPercentile(95)),
+		format(h.ValueAtPercentile(99)),
+		format(h.ValueAtPercentile(99.9)),
+		format(h.Max()),
+	}
 }
 
 func (t *Transactor) printResult() {
-	header := []string{"BatchNo", "Sample", "Fail", "Transaction/s", "TotalTime", "MinResponseTime", "MaxResponseTime", "AvgResponseTime"}
+	header := append([]string{"BatchNo", "Sample", "Fail", "Transaction/s", "TotalTime"}, latencyHeader...)
 	formatResult := func(rs *Result) []string {
 		totalTxCount := rs.TotalTxCount.Load()
 		totalTime := rs.EndTime.Sub(rs.StartTime)
@@ -452,11 +994,8 @@ func (t *Transactor) printResult() {
 			strconv.FormatInt(rs.TotalFailedTxCount, 10),
 			strconv.FormatFloat(float64(totalTxCount-rs.TotalFailedTxCount)/totalTime.Seconds(), 'f', 6, 64),
 			rs.EndTime.Sub(rs.StartTime).String(),
-			(time.Duration(rs.MinResponseTime) * time.Nanosecond).String(),
-			(time.Duration(rs.MaxResponseTime) * time.Nanosecond).String(),
-			(time.Duration((rs.MaxResponseTime+rs.MinResponseTime)/2) * time.Nanosecond).String(),
 		}
-		return row
+		return append(row, formatLatency(rs.ResponseTime)...)
 	}
 	if t.totalBatch > 1 && t.sendMode == Segment {
 		fmt.Println("Output segmented statistics:")
@@ -475,6 +1014,63 @@ func (t *Transactor) printResult() {
 		table.Render()
 	}
 
+	if len(t.profile) > 0 {
+		fmt.Println("Output stage statistics:")
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader(append([]string{"Stage", "Users", "Rate"}, header[1:]...))
+		table.SetAutoFormatHeaders(false)
+
+		for idx, stage := range t.profile {
+			rs, ok := t.stages[idx]
+			if !ok {
+				continue
+			}
+			row := []string{
+				stage.Name,
+				strconv.Itoa(stage.Users),
+				strconv.FormatFloat(stage.Rate, 'f', 2, 64),
+			}
+			table.Append(append(row, formatResult(rs)[1:]...))
+		}
+		table.Render()
+	}
+
+	if len(t.endpoints.Endpoints()) > 1 {
+		fmt.Println("Output endpoint statistics:")
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader(append([]string{"Endpoint"}, header[1:]...))
+		table.SetAutoFormatHeaders(false)
+
+		for _, endpoint := range t.endpoints.Endpoints() {
+			rs, ok := t.endpointResults[endpoint.URL]
+			if !ok {
+				continue
+			}
+			table.Append(append([]string{endpoint.URL}, formatResult(rs)[1:]...))
+		}
+		table.Render()
+	}
+
+	if t.gen.mix != nil {
+		fmt.Println("Output mix statistics:")
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader(append([]string{"Entry", "Weight"}, header[1:]...))
+		table.SetAutoFormatHeaders(false)
+
+		for _, entry := range t.mixEntries() {
+			rs, ok := t.entryResults[entry.Name]
+			if !ok {
+				continue
+			}
+			row := []string{entry.Name, strconv.Itoa(entry.Weight)}
+			table.Append(append(row, formatResult(rs)[1:]...))
+		}
+		table.Render()
+	}
+
 	fmt.Println("Output total statistics:")
 
 	table := tablewriter.NewWriter(os.Stdout)
@@ -482,4 +1078,18 @@ func (t *Transactor) printResult() {
 	table.SetAutoFormatHeaders(false)
 	table.Append(formatResult(t.rs))
 	table.Render()
+
+	t.printErrors()
+	t.nonces.printResult()
+	t.verifer.printResult()
+	t.watcher.printResult()
+
+	if t.sendMode == Rate && len(t.profile) == 0 {
+		// only the transactions accepted by the node count toward the rate
+		var achieved float64
+		if totalTime := t.rs.EndTime.Sub(t.rs.StartTime).Seconds(); totalTime > 0 {
+			achieved = float64(t.rs.TotalTxCount.Load()-t.rs.TotalFailedTxCount) / totalTime
+		}
+		fmt.Printf("Target rate: %.2f tx/s, achieved: %.2f tx/s\n", t.targetRate, achieved)
+	}
 }
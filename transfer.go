@@ -0,0 +1,173 @@
+package tester
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+)
+
+// RandomRecipient is the recipient of ParseRecipient sending every transfer to a new random address.
+const RandomRecipient = "random"
+
+// Recipient returns the recipient and the value of the next transfer sent by opts.From.
+//
+// It returns ErrExit when there is nothing left to transfer.
+type Recipient func(opts *bind.TransactOpts) (common.Address, *big.Int, error)
+
+// NewTransferTx returns a CreateTx sending native tokens without any call data.
+//
+// Parameters:
+// - backend: the backend used to fill in the missing transaction fields.
+// - next: returns the recipient and the value of every transfer.
+//
+// Returns:
+// - CreateTx: the transfer transaction builder.
+func NewTransferTx(backend bind.ContractBackend, next Recipient) CreateTx {
+	return func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		to, value, err := next(opts)
+		if err != nil {
+			return nil, err
+		}
+		opts.Value = value
+		if opts.GasLimit == 0 {
+			opts.GasLimit = params.TxGas
+		}
+		return bind.NewBoundContract(to, abi.ABI{}, backend, backend, backend).RawTransact(opts, nil)
+	}
+}
+
+// ToRecipients returns a Recipient sending the same value to every address once, in order.
+//
+// Parameters:
+// - recipients: the addresses receiving the value.
+// - value: the value sent to every address.
+//
+// Returns:
+// - Recipient: returns ErrExit once every address has been used.
+func ToRecipients(recipients []common.Address, value *big.Int) Recipient {
+	return inOrder(recipients, value, false)
+}
+
+// ToAddress returns a Recipient sending the same value to the same address, endlessly.
+//
+// Parameters:
+// - to: the address receiving the value.
+// - value: the value of every transfer.
+//
+// Returns:
+// - Recipient: never returns ErrExit.
+func ToAddress(to common.Address, value *big.Int) Recipient {
+	return func(opts *bind.TransactOpts) (common.Address, *big.Int, error) {
+		return to, new(big.Int).Set(value), nil
+	}
+}
+
+// ToRandom returns a Recipient sending the same value to a new random address every time, endlessly.
+//
+// Parameters:
+// - value: the value of every transfer.
+//
+// Returns:
+// - Recipient: never returns ErrExit.
+func ToRandom(value *big.Int) Recipient {
+	return func(opts *bind.TransactOpts) (common.Address, *big.Int, error) {
+		var to common.Address
+		if _, err := rand.Read(to[:]); err != nil {
+			return common.Address{}, nil, err
+		}
+		return to, new(big.Int).Set(value), nil
+	}
+}
+
+// CycleRecipients returns a Recipient sending the same value to the addresses in turn, endlessly.
+//
+// Parameters:
+// - recipients: the addresses receiving the value, at least one.
+// - value: the value of every transfer.
+//
+// Returns:
+// - Recipient: never returns ErrExit.
+func CycleRecipients(recipients []common.Address, value *big.Int) Recipient {
+	return inOrder(recipients, value, true)
+}
+
+// inOrder returns a Recipient sending value to the recipients in order, once
+// each, or in turn endlessly when cycle is set.
+func inOrder(recipients []common.Address, value *big.Int, cycle bool) Recipient {
+	var next atomic.Int64
+	return func(opts *bind.TransactOpts) (common.Address, *big.Int, error) {
+		n := next.Add(1) - 1
+		if cycle {
+			n %= int64(len(recipients))
+		} else if n >= int64(len(recipients)) {
+			return common.Address{}, nil, ErrExit
+		}
+		return recipients[n], new(big.Int).Set(value), nil
+	}
+}
+
+// ParseRecipient parses the recipients and the value of the transfers.
+//
+// Parameters:
+// - to: RandomRecipient alone for a new address every transfer, one address,
+// or several addresses used in turn.
+// - value: the value of every transfer in wei.
+//
+// Returns:
+// - Recipient: the recipients of the transfers, it never returns ErrExit.
+// - *big.Int: the value of every transfer.
+// - error: An error if an address or the value is invalid.
+func ParseRecipient(to []string, value string) (Recipient, *big.Int, error) {
+	amount, ok := new(big.Int).SetString(value, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, nil, errors.Errorf("invalid transfer value: %s", value)
+	}
+	if len(to) == 1 && to[0] == RandomRecipient {
+		return ToRandom(amount), amount, nil
+	}
+
+	recipients := make([]common.Address, 0, len(to))
+	for _, addr := range to {
+		if !common.IsHexAddress(addr) {
+			return nil, nil, errors.Errorf("invalid address: %s", addr)
+		}
+		recipients = append(recipients, common.HexToAddress(addr))
+	}
+	switch len(recipients) {
+	case 0:
+		return nil, nil, errors.New("no transfer recipient")
+	case 1:
+		return ToAddress(recipients[0], amount), amount, nil
+	default:
+		return CycleRecipients(recipients, amount), amount, nil
+	}
+}
+
+// FromSenders returns a Recipient sending a fixed value from every sender to the same address, once per sender.
+//
+// Parameters:
+// - to: the address receiving the values.
+// - values: the value sent by every sender.
+//
+// Returns:
+// - Recipient: returns ErrExit once every sender has been used.
+func FromSenders(to common.Address, values map[common.Address]*big.Int) Recipient {
+	var next atomic.Int64
+	return func(opts *bind.TransactOpts) (common.Address, *big.Int, error) {
+		if next.Add(1) > int64(len(values)) {
+			return common.Address{}, nil, ErrExit
+		}
+		value, ok := values[opts.From]
+		if !ok {
+			return common.Address{}, nil, errors.Errorf("no value to send from %s", opts.From)
+		}
+		return to, new(big.Int).Set(value), nil
+	}
+}
@@ -0,0 +1,72 @@
+package tester
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecipient(t *testing.T) {
+	a := "0x0000000000000000000000000000000000000001"
+	b := "0x0000000000000000000000000000000000000002"
+
+	testCases := []struct {
+		name    string
+		to      []string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{"random", []string{RandomRecipient}, "7", nil, false},
+		{"one address", []string{a}, "7", []string{a, a, a}, false},
+		{"cycling order", []string{a, b}, "0", []string{a, b, a, b, a}, false},
+		{"invalid address", []string{a, "0x01x"}, "7", nil, true},
+		{"random among addresses", []string{a, RandomRecipient}, "7", nil, true},
+		{"no recipient", nil, "7", nil, true},
+		{"negative value", []string{a}, "-1", nil, true},
+		{"invalid value", []string{a}, "1e18", nil, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, value, err := ParseRecipient(tc.to, tc.value)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.value, value.String())
+
+			opts := &bind.TransactOpts{}
+			if tc.want == nil {
+				// a new address every transfer
+				first, _, err := next(opts)
+				require.NoError(t, err)
+				second, _, err := next(opts)
+				require.NoError(t, err)
+				require.NotEqual(t, first, second)
+				return
+			}
+			for _, want := range tc.want {
+				to, v, err := next(opts)
+				require.NoError(t, err)
+				require.Equal(t, common.HexToAddress(want), to)
+				require.Equal(t, value, v)
+			}
+		})
+	}
+}
+
+func TestToRecipients(t *testing.T) {
+	recipients := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+	next := ToRecipients(recipients, big.NewInt(1))
+	for _, want := range recipients {
+		to, _, err := next(&bind.TransactOpts{})
+		require.NoError(t, err)
+		require.Equal(t, want, to)
+	}
+	_, _, err := next(&bind.TransactOpts{})
+	require.ErrorIs(t, err, ErrExit)
+}
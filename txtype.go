@@ -0,0 +1,222 @@
+package tester
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TxLegacy represents the legacy transactions, priced with a gas price.
+	TxLegacy TxType = "legacy"
+	// TxAccessList represents the EIP-2930 transactions, priced with a gas price and carrying an access list.
+	TxAccessList TxType = "access-list"
+	// TxDynamicFee represents the EIP-1559 transactions, the ones bind.TransactOpts builds on the chains with a base fee.
+	TxDynamicFee TxType = "dynamic-fee"
+)
+
+// TxType represents the type of the generated transactions.
+type TxType string
+
+// ParseTxType parses the input string and returns the corresponding TxType.
+//
+// Parameters:
+// - txType: The input string to be parsed.
+//
+// Return types:
+// - TxType: The corresponding TxType constant.
+// - error: An error if the input string does not match any of the defined types.
+func ParseTxType(txType string) (TxType, error) {
+	switch txType {
+	case string(TxLegacy):
+		return TxLegacy, nil
+	case string(TxAccessList):
+		return TxAccessList, nil
+	case string(TxDynamicFee):
+		return TxDynamicFee, nil
+	default:
+		return "", fmt.Errorf("invalid transaction type: %s", txType)
+	}
+}
+
+// ApplyTxType makes opts build transactions of type txType, with the access
+// lists of lists when it is not nil.
+//
+// The legacy and access list transactions are priced with the fee cap of opts,
+// which is then the price paid for the whole gas used, or with the gas price
+// suggested by backend when opts has no fee cap. The estimated gas limit of a
+// transaction is raised to cover its access list.
+//
+// Parameters:
+// - opts: the transactor options, its fee caps and its signer are changed.
+// - txType: the type of the transactions.
+// - lists: the access lists of the contract methods, nil to send no access list.
+// - backend: the backend suggesting the gas price.
+//
+// Returns:
+// - error: An error if the gas price can not be suggested.
+func ApplyTxType(opts *bind.TransactOpts, txType TxType, lists *AccessLists, backend bind.ContractTransactor) error {
+	if txType == TxLegacy || txType == TxAccessList {
+		gasPrice := opts.GasFeeCap
+		if gasPrice == nil {
+			// without a gas price bind would build a dynamic fee transaction
+			// and the signer would take its fee cap as the gas price
+			ctx := opts.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			price, err := backend.SuggestGasPrice(ctx)
+			if err != nil {
+				return errors.Wrap(err, "failed to suggest the gas price")
+			}
+			gasPrice = price
+		}
+		// a gas price makes bind build a legacy transaction
+		opts.GasPrice = gasPrice
+		opts.GasFeeCap, opts.GasTipCap = nil, nil
+	}
+	if txType != TxLegacy && txType != TxAccessList && lists == nil {
+		return nil
+	}
+
+	signer := opts.Signer
+	opts.Signer = func(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		f := fieldsOf(tx)
+		var typ uint8
+		switch txType {
+		case TxLegacy:
+			typ = types.LegacyTxType
+		case TxAccessList:
+			typ = types.AccessListTxType
+		default:
+			// bind builds legacy transactions on the chains without a base fee
+			typ = tx.Type()
+		}
+		if lists != nil && typ != types.LegacyTxType {
+			accessList, gasUsed, err := lists.Get(opts.Context, from, tx)
+			if err != nil {
+				return nil, err
+			}
+			f.accessList = accessList
+			if opts.GasLimit == 0 {
+				// bind estimated the gas without the access list, it costs its
+				// intrinsic gas on top and never makes the execution dearer
+				f.gas += accessListGas(accessList)
+				if f.gas < gasUsed {
+					f.gas = gasUsed
+				}
+			}
+		}
+
+		// the chain ID is set by the signer
+		data, err := newTxData(typ, nil, f)
+		if err != nil {
+			return nil, err
+		}
+		return signer(from, types.NewTx(data))
+	}
+	return nil
+}
+
+// accessListGas returns the intrinsic gas of an access list.
+func accessListGas(list types.AccessList) uint64 {
+	return uint64(len(list))*params.TxAccessListAddressGas +
+		uint64(list.StorageKeys())*params.TxAccessListStorageKeyGas
+}
+
+// AccessLists precomputes the access list of every contract method with
+// eth_createAccessList, once per method and contract.
+type AccessLists struct {
+	eth *gethclient.Client
+
+	mu    sync.Mutex
+	lists map[accessListKey]*accessListEntry
+}
+
+type accessListKey struct {
+	to       common.Address
+	create   bool
+	selector [4]byte
+}
+
+// accessListEntry is the access list of a method, mu is held while it is created.
+type accessListEntry struct {
+	mu      sync.Mutex
+	done    bool
+	list    types.AccessList
+	gasUsed uint64
+}
+
+// NewAccessLists creates a new AccessLists.
+//
+// Parameters:
+// - eth: the client the access lists are created with.
+//
+// Returns:
+// - *AccessLists: the access lists, empty until a method is called.
+func NewAccessLists(eth *ethclient.Client) *AccessLists {
+	return &AccessLists{
+		eth:   gethclient.New(eth.Client()),
+		lists: make(map[accessListKey]*accessListEntry),
+	}
+}
+
+// Get returns the access list of the method called by tx and the gas used by
+// the call with the list, it is created by the first call of the method and
+// reused by the next ones. Only the calls of the same method wait for each other.
+func (a *AccessLists) Get(ctx context.Context, from common.Address, tx *types.Transaction) (types.AccessList, uint64, error) {
+	if tx.To() != nil && len(tx.Data()) == 0 {
+		// a plain transfer calls no method
+		return nil, 0, nil
+	}
+
+	key := accessListKey{create: tx.To() == nil}
+	if tx.To() != nil {
+		key.to = *tx.To()
+	}
+	copy(key.selector[:], tx.Data())
+
+	a.mu.Lock()
+	entry, ok := a.lists[key]
+	if !ok {
+		entry = &accessListEntry{}
+		a.lists[key] = entry
+	}
+	a.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.done {
+		return entry.list, entry.gasUsed, nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	list, gasUsed, vmErr, err := a.eth.CreateAccessList(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	})
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to create the access list")
+	}
+	if vmErr != "" {
+		return nil, 0, errors.Errorf("failed to create the access list: %s", vmErr)
+	}
+	if list != nil {
+		entry.list = *list
+	}
+	entry.gasUsed, entry.done = gasUsed, true
+	return entry.list, entry.gasUsed, nil
+}
@@ -0,0 +1,103 @@
+package tester
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTxType(t *testing.T) {
+	for _, txType := range []TxType{TxLegacy, TxAccessList, TxDynamicFee} {
+		parsed, err := ParseTxType(string(txType))
+		require.NoError(t, err)
+		require.Equal(t, txType, parsed)
+	}
+	for _, txType := range []string{"", "blob", "Legacy", "eip1559"} {
+		_, err := ParseTxType(txType)
+		require.Error(t, err, txType)
+	}
+}
+
+func TestApplyTxType(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		from: {Balance: big.NewInt(params.Ether)},
+	}, 30_000_000)
+	defer backend.Close()
+
+	to := common.HexToAddress("0x01")
+	dynamic := types.NewTx(&types.DynamicFeeTx{
+		Nonce:     3,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(5),
+	})
+
+	suggested, err := backend.SuggestGasPrice(context.Background())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		txType    TxType
+		gasFeeCap *big.Int
+		wantType  uint8
+		wantPrice *big.Int
+	}{
+		{"legacy", TxLegacy, big.NewInt(7), types.LegacyTxType, big.NewInt(7)},
+		{"legacy without fee cap", TxLegacy, nil, types.LegacyTxType, suggested},
+		{"access list", TxAccessList, big.NewInt(7), types.AccessListTxType, big.NewInt(7)},
+		{"access list without fee cap", TxAccessList, nil, types.AccessListTxType, suggested},
+		{"dynamic fee", TxDynamicFee, big.NewInt(7), types.DynamicFeeTxType, big.NewInt(100)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+			require.NoError(t, err)
+			opts.GasFeeCap, opts.GasTipCap = tc.gasFeeCap, big.NewInt(1)
+
+			require.NoError(t, ApplyTxType(opts, tc.txType, nil, backend))
+			if tc.txType != TxDynamicFee {
+				require.Equal(t, tc.wantPrice, opts.GasPrice)
+				require.Nil(t, opts.GasFeeCap)
+				require.Nil(t, opts.GasTipCap)
+			}
+
+			// bind hands the signer a dynamic fee transaction on the chains with a base fee
+			tx, err := opts.Signer(from, dynamic)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantType, tx.Type())
+			require.Equal(t, dynamic.Nonce(), tx.Nonce())
+			require.Equal(t, dynamic.Gas(), tx.Gas())
+			require.Equal(t, dynamic.To(), tx.To())
+			require.Equal(t, dynamic.Value(), tx.Value())
+			if tc.txType != TxDynamicFee {
+				// the signer takes the fee cap of the transaction as gas price
+				require.Equal(t, dynamic.GasFeeCap(), tx.GasPrice())
+			}
+			sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1337)), tx)
+			require.NoError(t, err)
+			require.Equal(t, from, sender)
+		})
+	}
+}
+
+func TestAccessListGas(t *testing.T) {
+	require.Zero(t, accessListGas(nil))
+	list := types.AccessList{
+		{Address: common.HexToAddress("0x01"), StorageKeys: []common.Hash{{1}, {2}}},
+		{Address: common.HexToAddress("0x02")},
+	}
+	require.Equal(t, 2*params.TxAccessListAddressGas+2*params.TxAccessListStorageKeyGas, accessListGas(list))
+}
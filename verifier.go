@@ -2,59 +2,217 @@ package tester
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/olekukonko/tablewriter"
 	"golang.org/x/exp/slog"
 )
 
-const maxFailedCounter = 10
+const (
+	maxFailedCounter = 10
+	// maxWaitBlocks is the number of blocks a transaction may be missing from
+	// before the block verify mode gives up on it.
+	maxWaitBlocks = 100
+)
+
+const (
+	// ReceiptVerify represents polling the receipt of every transaction.
+	ReceiptVerify VerifyMode = "receipt"
+	// BlockVerify represents matching the transactions against every new block.
+	BlockVerify VerifyMode = "block"
+)
+
+// VerifyMode represents the way the Verifier finds the receipts.
+type VerifyMode string
+
+// ParseVerifyMode parses the input string and returns the corresponding
+// VerifyMode constant if it matches one of the defined modes. Otherwise, it
+// returns an error.
+//
+// Parameters:
+// - mode: The input string to be parsed.
+//
+// Return types:
+// - VerifyMode: The corresponding VerifyMode constant.
+// - error: An error if the input string does not match any defined modes.
+func ParseVerifyMode(mode string) (VerifyMode, error) {
+	switch mode {
+	case string(ReceiptVerify):
+		return ReceiptVerify, nil
+	case string(BlockVerify):
+		return BlockVerify, nil
+	default:
+		return "", fmt.Errorf("invalid verify mode: %s", mode)
+	}
+}
 
 type element struct {
 	hash          common.Hash
+	sentAt        time.Time
+	head          uint64
 	failedCounter atomic.Int32
+	waitedBlocks  int
+	sent          bool
+
+	// the last replacement of a stuck transaction, and the hashes of the
+	// original transaction and of all its replacements, any of them may be included
+	from         common.Address
+	entry        string
+	tx           *types.Transaction
+	hashes       []common.Hash
+	lastSentAt   time.Time
+	replacements int
 }
 
 type record struct {
-	Hash   string `csv:"hash"`
-	Status string `csv:"status"`
+	Hash              string `csv:"hash"`
+	Status            string `csv:"status"`
+	SentAt            string `csv:"sent_at"`
+	BlockNumber       uint64 `csv:"block_number"`
+	ReceiptLatency    int64  `csv:"receipt_latency_ms"`
+	InclusionLatency  int64  `csv:"inclusion_latency_ms"`
+	BlocksToInclusion uint64 `csv:"blocks_to_inclusion"`
+	Replacements      int    `csv:"replacements"`
+	IncludedHash      string `csv:"included_hash"`
+	BlobGasUsed       uint64 `csv:"blob_gas_used"`
+	BlobGasPrice      string `csv:"blob_gas_price"`
+	Entry             string `csv:"entry"`
 }
 
 // Verifier is a struct that verifies the hashes in the queue.
 type Verifier struct {
 	enable  bool
+	mode    VerifyMode
 	queue   *Queue[*element]
 	timer   *time.Ticker
 	eth     *ethclient.Client
-	records []*record
+	metrics *Metrics
+	stuck   *replacer
+	mix     *Mix
+	head    atomic.Uint64
+	stop    chan struct{}
+	once    sync.Once
+
+	mu               sync.Mutex
+	pending          map[common.Hash]*element
+	records          []*record
+	blockTimes       map[uint64]time.Time
+	receiptLatency   *Histogram
+	inclusionLatency *Histogram
+	inclusionBlocks  *Histogram
+	blobs            blobStats
+
+	// the inclusions recorded as zero: latencies below the one second precision
+	// of the block timestamps, and blocks older than the head at the send
+	negativeLatencies int64
+	negativeBlocks    int64
+}
+
+// blobStats sums up the blob transactions found in the receipts.
+type blobStats struct {
+	txs      int64
+	gasUsed  uint64
+	minPrice *big.Int
+	maxPrice *big.Int
 }
 
 // NewVerifier creates a new Verifier instance.
 //
 // enable: a boolean indicating whether the Verifier is enabled.
 // eth: an instance of ethclient.Client used for interacting with the Ethereum blockchain.
+// mode: the way the receipts are found, the receipt mode works with any endpoint.
 // Returns a pointer to the newly created Verifier instance.
-func NewVerifier(enable bool, eth *ethclient.Client) *Verifier {
+func NewVerifier(enable bool, eth *ethclient.Client, mode VerifyMode) *Verifier {
 	return &Verifier{
-		enable: enable,
-		queue:  NewQueue[*element](),
-		timer:  time.NewTicker(10 * time.Second),
-		eth:    eth,
+		enable:           enable,
+		mode:             mode,
+		queue:            NewQueue[*element](),
+		pending:          make(map[common.Hash]*element),
+		stop:             make(chan struct{}),
+		timer:            time.NewTicker(10 * time.Second),
+		eth:              eth,
+		blockTimes:       make(map[uint64]time.Time),
+		receiptLatency:   NewHistogram(),
+		inclusionLatency: NewHistogram(),
+		inclusionBlocks:  NewHistogram(),
 	}
 }
 
-// Add adds a hash to the Verifier.
+// Add registers a transaction about to be sent, Sent must be called with the
+// outcome of the send.
 //
-// The parameter `hash` is the hash to be added to the Verifier.
-func (v *Verifier) Add(hash common.Hash) {
-	if v.enable {
-		v.queue.Add(&element{
-			hash: hash,
-		})
+// The transaction is registered before it is sent, so that the block verify
+// mode matches it even when it is included before the send returns.
+//
+// The parameter `tx` is the transaction to be verified, `from` is its sender,
+// `entry` its mix entry and `sentAt` is the time the transaction was sent at.
+func (v *Verifier) Add(tx *types.Transaction, from common.Address, entry string, sentAt time.Time) {
+	if !v.enable {
+		return
 	}
+	ele := &element{
+		hash:       tx.Hash(),
+		sentAt:     sentAt,
+		head:       v.head.Load(),
+		from:       from,
+		entry:      entry,
+		tx:         tx,
+		hashes:     []common.Hash{tx.Hash()},
+		lastSentAt: sentAt,
+	}
+	v.mu.Lock()
+	v.pending[ele.hash] = ele
+	v.mu.Unlock()
+}
+
+// Sent hands over the outcome of the send of a transaction registered with
+// Add, a transaction rejected by the node is not verified.
+func (v *Verifier) Sent(hash common.Hash, err error) {
+	if !v.enable {
+		return
+	}
+	v.mu.Lock()
+	ele, ok := v.pending[hash]
+	// an element already matched against a block has been recorded
+	if ok && (err != nil || v.mode == ReceiptVerify) {
+		delete(v.pending, hash)
+	}
+	if ok && err == nil {
+		ele.sent = true
+	}
+	mode := v.mode
+	v.mu.Unlock()
+
+	if ok && err == nil && mode == ReceiptVerify {
+		v.queue.Add(ele)
+	}
+}
+
+// replace replaces the last transaction of a stuck element, it returns the
+// replacement or nil when it could not be sent.
+func (v *Verifier) replace(ele *element) *types.Transaction {
+	tx, err := v.stuck.replace(context.Background(), ele)
+	if err != nil {
+		slog.Error("failed to replace stuck transaction", "hash", ele.tx.Hash(), "err", err)
+		// try again once the stuck period is over
+		ele.lastSentAt = time.Now()
+		return nil
+	}
+	ele.tx = tx
+	ele.hashes = append(ele.hashes, tx.Hash())
+	ele.lastSentAt = time.Now()
+	ele.replacements++
+	return tx
 }
 
 // Start verifies the Verifier.
@@ -65,29 +223,38 @@ func (v *Verifier) Start(parallelable bool) {
 	if !v.enable {
 		return
 	}
+	if v.mode == BlockVerify {
+		err := v.followBlocks()
+		if err == nil {
+			return
+		}
+		slog.Error("failed to follow blocks, fall back to the receipt verify mode", "err", err)
+		v.fallback()
+	}
+	go v.trackHead()
 
 	validate := func(ele *element) bool {
 		if ele.failedCounter.Load() >= maxFailedCounter {
-			v.records = append(v.records, &record{
-				Hash:   ele.hash.String(),
-				Status: "failed",
-			})
+			v.addRecord(ele.record())
 			return true
 		}
-		receipt, err := v.eth.TransactionReceipt(context.Background(), ele.hash)
-		if err != nil || receipt == nil {
-			ele.failedCounter.Add(1)
-			return false
+		if receipt := v.receipt(ele); receipt != nil {
+			v.addReceipt(ele, receipt, time.Now())
+			return true
 		}
-		record := &record{
-			Hash:   ele.hash.String(),
-			Status: "failed",
+		stuck := v.stuck.stuck(ele, time.Now())
+		if stuck && v.replace(ele) != nil {
+			// give the replacement as much time as the original transaction
+			ele.failedCounter.Store(0)
+			return false
 		}
-		if receipt.Status == types.ReceiptStatusSuccessful {
-			record.Status = "success"
+		if !stuck && v.stuck.owns(ele) {
+			// the stuck policy decides how long the transaction waits, only
+			// the failed replacements give up on it
+			return false
 		}
-		v.records = append(v.records, record)
-		return true
+		ele.failedCounter.Add(1)
+		return false
 	}
 	for range v.timer.C {
 		slog.Info("verify transactions", "left", v.queue.Length())
@@ -99,6 +266,266 @@ func (v *Verifier) Start(parallelable bool) {
 	}
 }
 
+// trackHead keeps the latest block number up to date, so that the number of
+// blocks a transaction waited for can be computed.
+func (v *Verifier) trackHead() {
+	tick := time.NewTicker(1 * time.Second)
+	defer tick.Stop()
+
+	for {
+		head, err := v.eth.BlockNumber(context.Background())
+		if err != nil {
+			slog.Error("failed to fetch the latest block number", "err", err)
+		} else {
+			v.head.Store(head)
+		}
+
+		select {
+		case <-tick.C:
+		case <-v.stop:
+			return
+		}
+	}
+}
+
+// fallback switches to the receipt verify mode, the transactions sent so far
+// are polled for their receipts.
+func (v *Verifier) fallback() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.mode = ReceiptVerify
+	for hash, ele := range v.pending {
+		if ele.sent && hash == ele.hash {
+			v.queue.Add(ele)
+		}
+		if ele.sent {
+			delete(v.pending, hash)
+		}
+	}
+}
+
+// followBlocks matches the pending transactions against every new block, it
+// returns an error if the blocks can not be followed.
+func (v *Verifier) followBlocks() error {
+	ctx := context.Background()
+	head, err := v.eth.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	v.head.Store(head)
+
+	go func() {
+		for {
+			select {
+			case <-v.timer.C:
+				slog.Info("verify transactions", "left", v.Pending())
+			case <-v.stop:
+				return
+			}
+		}
+	}()
+	followBlocks(ctx, v.eth, head, v.stop, v.verifyBlock)
+	return nil
+}
+
+// verifyBlock records the receipts of the pending transactions included in
+// block, and gives up on the ones that have been missing for too long.
+func (v *Verifier) verifyBlock(block *types.Block) {
+	seenAt := time.Now()
+	v.head.Store(block.NumberU64())
+
+	var (
+		included       []*element
+		includedHashes []common.Hash
+	)
+	v.mu.Lock()
+	for _, tx := range block.Transactions() {
+		if ele, ok := v.pending[tx.Hash()]; ok {
+			included = append(included, ele)
+			includedHashes = append(includedHashes, tx.Hash())
+			for _, hash := range ele.hashes {
+				delete(v.pending, hash)
+			}
+		}
+	}
+	var expired, stuck []*element
+	for hash, ele := range v.pending {
+		// the replacements share the element of the original transaction, and
+		// a transaction still being sent has not waited for any block yet
+		if hash != ele.hash || !ele.sent {
+			continue
+		}
+		if v.stuck.owns(ele) {
+			// the stuck policy decides how long the transaction waits, it is
+			// given up after as many failed replacements as in the receipt mode
+			if ele.failedCounter.Load() < maxFailedCounter {
+				if v.stuck.stuck(ele, seenAt) {
+					stuck = append(stuck, ele)
+				}
+				continue
+			}
+		} else {
+			ele.waitedBlocks++
+			if ele.waitedBlocks < maxWaitBlocks {
+				continue
+			}
+		}
+		expired = append(expired, ele)
+		for _, hash := range ele.hashes {
+			delete(v.pending, hash)
+		}
+	}
+	v.mu.Unlock()
+
+	for _, ele := range stuck {
+		tx := v.replace(ele)
+		v.mu.Lock()
+		if tx != nil {
+			// give the replacement as much time as the original transaction
+			ele.failedCounter.Store(0)
+			v.pending[tx.Hash()] = ele
+		} else {
+			ele.failedCounter.Add(1)
+		}
+		v.mu.Unlock()
+	}
+
+	for i, ele := range included {
+		receipt, err := v.eth.TransactionReceipt(context.Background(), includedHashes[i])
+		if err != nil {
+			slog.Error("failed to fetch receipt", "hash", includedHashes[i], "err", err)
+			rd := ele.record()
+			rd.BlockNumber = block.NumberU64()
+			v.addRecord(rd)
+			continue
+		}
+		v.addReceipt(ele, receipt, seenAt)
+	}
+	for _, ele := range expired {
+		// the block of the transaction may have been missed, ask for its
+		// receipt before giving up on it
+		if receipt := v.receipt(ele); receipt != nil {
+			v.addReceipt(ele, receipt, seenAt)
+			continue
+		}
+		v.addRecord(ele.record())
+	}
+}
+
+// receipt returns the receipt of the transaction of ele or of any of its
+// replacements, nil if none of them has been included.
+func (v *Verifier) receipt(ele *element) *types.Receipt {
+	for _, hash := range ele.hashes {
+		receipt, err := v.eth.TransactionReceipt(context.Background(), hash)
+		if err == nil && receipt != nil {
+			return receipt
+		}
+	}
+	return nil
+}
+
+// addReceipt records the receipt of a transaction found at receiptAt.
+func (v *Verifier) addReceipt(ele *element, receipt *types.Receipt, receiptAt time.Time) {
+	rd := ele.record()
+	rd.BlockNumber = receipt.BlockNumber.Uint64()
+	rd.ReceiptLatency = receiptAt.Sub(ele.sentAt).Milliseconds()
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		rd.Status = "success"
+	}
+	rd.Replacements, rd.IncludedHash = ele.replacements, receipt.TxHash.Hex()
+	if receipt.TxHash != ele.hash && v.stuck != nil && v.stuck.policy == StuckCancel {
+		rd.Status = "cancelled"
+	}
+	if receipt.BlobGasPrice != nil {
+		rd.BlobGasUsed, rd.BlobGasPrice = receipt.BlobGasUsed, receipt.BlobGasPrice.String()
+	}
+	// the head is unknown until it is fetched for the first time
+	recordBlocks := ele.head > 0
+	if recordBlocks && rd.BlockNumber >= ele.head {
+		rd.BlocksToInclusion = rd.BlockNumber - ele.head
+	}
+
+	// block timestamps only have a precision of one second, so the
+	// inclusion latency of a fast chain may be rounded down to zero
+	blockTime, err := v.blockTime(rd.BlockNumber)
+	if err != nil {
+		slog.Error("failed to fetch block", "number", rd.BlockNumber, "err", err)
+	} else {
+		rd.InclusionLatency = blockTime.Sub(ele.sentAt).Milliseconds()
+	}
+
+	v.mu.Lock()
+	v.receiptLatency.Record(receiptAt.Sub(ele.sentAt).Nanoseconds())
+	if err == nil {
+		latency := blockTime.Sub(ele.sentAt).Nanoseconds()
+		if latency < 0 {
+			v.negativeLatencies++
+		}
+		v.inclusionLatency.Record(latency)
+	}
+	if recordBlocks {
+		if rd.BlockNumber < ele.head {
+			// a reorg, or an endpoint behind the one the head is fetched from
+			slog.Warn("transaction included before the head it was sent at", "hash", ele.hash, "block", rd.BlockNumber, "head", ele.head)
+			v.negativeBlocks++
+		}
+		v.inclusionBlocks.Record(int64(rd.BlocksToInclusion))
+	}
+	if receipt.BlobGasPrice != nil {
+		v.blobs.add(receipt.BlobGasUsed, receipt.BlobGasPrice)
+	}
+	v.mu.Unlock()
+
+	v.addRecord(rd)
+}
+
+// record returns the record of a transaction failed until a receipt is found.
+func (ele *element) record() *record {
+	return &record{
+		Hash:   ele.hash.String(),
+		Status: "failed",
+		SentAt: ele.sentAt.Format(time.RFC3339Nano),
+		Entry:  ele.entry,
+	}
+}
+
+func (v *Verifier) addRecord(rd *record) {
+	v.mu.Lock()
+	v.records = append(v.records, rd)
+	v.mu.Unlock()
+
+	v.metrics.txVerified(rd.Status)
+}
+
+// blockTime returns the timestamp of the block with the given number.
+func (v *Verifier) blockTime(number uint64) (time.Time, error) {
+	v.mu.Lock()
+	blockTime, ok := v.blockTimes[number]
+	v.mu.Unlock()
+	if ok {
+		return blockTime, nil
+	}
+
+	header, err := v.eth.HeaderByNumber(context.Background(), new(big.Int).SetUint64(number))
+	if err != nil {
+		return time.Time{}, err
+	}
+	blockTime = time.Unix(int64(header.Time), 0)
+
+	v.mu.Lock()
+	v.blockTimes[number] = blockTime
+	v.mu.Unlock()
+	return blockTime, nil
+}
+
+// Pending returns the number of transactions waiting to be verified.
+func (v *Verifier) Pending() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.queue.Length() + len(v.pending)
+}
+
 // Finish checks if the Verifier has finished processing.
 //
 // It returns true if the Verifier is not enabled or if the queue length is zero,
@@ -107,10 +534,165 @@ func (v *Verifier) Finish(total int64) bool {
 	if !v.enable {
 		return true
 	}
-	if v.queue.IsEmpty() && int64(len(v.records)) == total {
+	v.mu.Lock()
+	verified := int64(len(v.records))
+	v.mu.Unlock()
+	// a transaction reported as failed by the node may still have been included
+	if v.Pending() == 0 && verified >= total {
 		v.timer.Stop()
+		v.once.Do(func() { close(v.stop) })
 		SaveToCSV("./result.csv", v.records)
 		return true
 	}
 	return false
 }
+
+// printResult prints the time it took the verified transactions to be included.
+func (v *Verifier) printResult() {
+	if !v.enable {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	fmt.Println("Output inclusion statistics:")
+
+	header := []string{"Measure", "Sample", "Min", "Mean", "P50", "P90", "P95", "P99", "P99.9", "Max"}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetAutoFormatHeaders(false)
+	table.Append(append([]string{"TimeToReceipt", strconv.FormatInt(v.receiptLatency.Count(), 10)}, formatLatency(v.receiptLatency)...))
+	table.Append(append([]string{"TimeToInclusion", strconv.FormatInt(v.inclusionLatency.Count(), 10)}, formatLatency(v.inclusionLatency)...))
+
+	blocks := v.inclusionBlocks
+	table.Append([]string{
+		"BlocksToInclusion",
+		strconv.FormatInt(blocks.Count(), 10),
+		strconv.FormatInt(blocks.Min(), 10),
+		strconv.FormatFloat(blocks.Mean(), 'f', 2, 64),
+		strconv.FormatInt(blocks.ValueAtPercentile(50), 10),
+		strconv.FormatInt(blocks.ValueAtPercentile(90), 10),
+		strconv.FormatInt(blocks.ValueAtPercentile(95), 10),
+		strconv.FormatInt(blocks.ValueAtPercentile(99), 10),
+		strconv.FormatInt(blocks.ValueAtPercentile(99.9), 10),
+		strconv.FormatInt(blocks.Max(), 10),
+	})
+	table.Render()
+	if v.negativeLatencies > 0 {
+		fmt.Printf("%d inclusion latencies below the one second precision of the block timestamps were recorded as 0\n", v.negativeLatencies)
+	}
+	if v.negativeBlocks > 0 {
+		fmt.Printf("%d transactions included before the head they were sent at were recorded as 0 blocks\n", v.negativeBlocks)
+	}
+
+	v.blobs.print()
+	v.printEntries()
+
+	if v.stuck == nil {
+		return
+	}
+	var included int
+	for _, rd := range v.records {
+		if rd.IncludedHash != "" && rd.IncludedHash != rd.Hash {
+			included++
+		}
+	}
+
+	fmt.Println("Output replacement statistics:")
+
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Policy", "Replaced", "ReplaceFailed", "ReplacementsIncluded"})
+	table.SetAutoFormatHeaders(false)
+	table.Append([]string{
+		string(v.stuck.policy),
+		strconv.FormatInt(v.stuck.replaced.Load(), 10),
+		strconv.FormatInt(v.stuck.failed.Load(), 10),
+		strconv.Itoa(included),
+	})
+	table.Render()
+}
+
+// printEntries prints the outcome of the verified transactions of every mix entry.
+func (v *Verifier) printEntries() {
+	if v.mix == nil {
+		return
+	}
+
+	type entryStats struct {
+		verified, success, failed, cancelled int64
+		receiptLatency                       *Histogram
+	}
+	stats := make(map[string]*entryStats)
+	for _, entry := range v.mix.Entries() {
+		stats[entry.Name] = &entryStats{receiptLatency: NewHistogram()}
+	}
+	for _, rd := range v.records {
+		st, ok := stats[rd.Entry]
+		if !ok {
+			continue
+		}
+		st.verified++
+		switch rd.Status {
+		case "success":
+			st.success++
+		case "cancelled":
+			st.cancelled++
+		default:
+			st.failed++
+		}
+		if rd.IncludedHash != "" {
+			st.receiptLatency.Record((time.Duration(rd.ReceiptLatency) * time.Millisecond).Nanoseconds())
+		}
+	}
+
+	fmt.Println("Output mix inclusion statistics:")
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Entry", "Verified", "Success", "Failed", "Cancelled", "MeanTimeToReceipt", "P99TimeToReceipt"})
+	table.SetAutoFormatHeaders(false)
+	for _, entry := range v.mix.Entries() {
+		st := stats[entry.Name]
+		latency := formatLatency(st.receiptLatency)
+		table.Append([]string{
+			entry.Name,
+			strconv.FormatInt(st.verified, 10),
+			strconv.FormatInt(st.success, 10),
+			strconv.FormatInt(st.failed, 10),
+			strconv.FormatInt(st.cancelled, 10),
+			latency[1],
+			latency[5],
+		})
+	}
+	table.Render()
+}
+
+func (b *blobStats) add(gasUsed uint64, price *big.Int) {
+	b.txs++
+	b.gasUsed += gasUsed
+	if b.minPrice == nil || price.Cmp(b.minPrice) < 0 {
+		b.minPrice = price
+	}
+	if b.maxPrice == nil || price.Cmp(b.maxPrice) > 0 {
+		b.maxPrice = price
+	}
+}
+
+// print prints the blob gas used and the blob base fees paid, when blob transactions were included.
+func (b *blobStats) print() {
+	if b.txs == 0 {
+		return
+	}
+	fmt.Println("Output blob statistics:")
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"BlobTxs", "Blobs", "BlobGasUsed", "MinBlobBaseFee", "MaxBlobBaseFee"})
+	table.SetAutoFormatHeaders(false)
+	table.Append([]string{
+		strconv.FormatInt(b.txs, 10),
+		strconv.FormatUint(b.gasUsed/params.BlobTxBlobGasPerBlob, 10),
+		strconv.FormatUint(b.gasUsed, 10),
+		b.minPrice.String(),
+		b.maxPrice.String(),
+	})
+	table.Render()
+}